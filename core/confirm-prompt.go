@@ -2,7 +2,10 @@ package core
 
 import (
 	"context"
-	"os"
+	"fmt"
+	"io"
+	"strings"
+	"time"
 
 	"github.com/orochaa/go-clack/core/utils"
 	"github.com/orochaa/go-clack/core/validator"
@@ -15,13 +18,19 @@ type ConfirmPrompt struct {
 }
 
 type ConfirmPromptParams struct {
-	Context      context.Context
-	Input        *os.File
-	Output       *os.File
-	Active       string
-	Inactive     string
-	InitialValue bool
-	Render       func(p *ConfirmPrompt) string
+	Context       context.Context
+	Input         io.Reader
+	Output        io.Writer
+	Active        string
+	Inactive      string
+	InitialValue  bool
+	Timeout       time.Duration
+	TimeoutCancel bool
+	Ephemeral     bool
+	LineMode      bool
+	Summary       func(value bool) string
+	Settings      *SettingsOptions
+	Render        func(p *ConfirmPrompt) string
 }
 
 // NewConfirmPrompt initializes and returns a new instance of ConfirmPrompt.
@@ -33,11 +42,17 @@ type ConfirmPromptParams struct {
 //
 // Parameters:
 //   - Context (context.Context): The context for the prompt (default: context.Background).
-//   - Input (*os.File): The input stream for the prompt (default: OSFileSystem).
-//   - Output (*os.File): The output stream for the prompt (default: OSFileSystem).
+//   - Input (io.Reader): The input stream for the prompt (default: os.Stdin).
+//   - Output (io.Writer): The output stream for the prompt (default: os.Stdout).
 //   - Active (string): The label displayed when the prompt is in the "active" (true) state (default: "yes").
 //   - Inactive (string): The label displayed when the prompt is in the "inactive" (false) state (default: "no").
 //   - InitialValue (bool): The initial value of the prompt (default: false).
+//   - Timeout (time.Duration): The time after which the current value is submitted, unless the user presses a key before (default: 0, no timeout).
+//   - TimeoutCancel (bool): Whether to cancel the prompt with ErrTimeout instead of submitting the current value on timeout (default: false).
+//   - Ephemeral (bool): Whether to erase the prompt once submitted, or replace it by its Summary (default: false).
+//   - LineMode (bool): Whether to read whole lines as answers, without raw mode or cursor movements, as done when the input is not a terminal (default: false).
+//   - Summary (func(value bool) string): The one-line summary of the submitted value of an ephemeral prompt (default: nil, the prompt is erased).
+//   - Settings (*SettingsOptions): The key bindings and options of the prompt (default: the global Settings).
 //   - Render (func(p *MultiSelectPathPrompt) string): A custom render function for the prompt (default: nil).
 //
 // Returns:
@@ -56,25 +71,31 @@ func NewConfirmPrompt(params ConfirmPromptParams) *ConfirmPrompt {
 	var p ConfirmPrompt
 	p = ConfirmPrompt{
 		Prompt: *NewPrompt(PromptParams[bool]{
-			Context:      params.Context,
-			Input:        params.Input,
-			Output:       params.Output,
-			InitialValue: params.InitialValue,
-			Render:       WrapRender[bool](&p, params.Render),
+			Context:       params.Context,
+			Input:         params.Input,
+			Output:        params.Output,
+			InitialValue:  params.InitialValue,
+			ParseLine:     p.parseLine,
+			Timeout:       params.Timeout,
+			TimeoutCancel: params.TimeoutCancel,
+			Ephemeral:     params.Ephemeral,
+			LineMode:      params.LineMode,
+			Summary:       params.Summary,
+			Settings:      params.Settings,
+			Render:        WrapRender[bool](&p, params.Render),
 		}),
 		Active:   params.Active,
 		Inactive: params.Inactive,
 	}
 
-	actionHandler := NewActionHandler(map[Action]func(){
+	actionHandler := p.NewActionHandler(map[Action]func(){
 		UpAction:    p.toggleValue,
 		DownAction:  p.toggleValue,
 		LeftAction:  p.toggleValue,
 		RightAction: p.toggleValue,
 	}, nil)
-	p.On(KeyEvent, func(args ...any) {
-		actionHandler(args[0].(*Key))
-	})
+	p.typesKeys = func() bool { return false }
+	p.OnKey(actionHandler)
 
 	return &p
 }
@@ -85,3 +106,34 @@ func (p *ConfirmPrompt) toggleValue() {
 	p.CursorIndex = utils.MinMaxIndex(p.CursorIndex+1, 2)
 	p.Value = !p.Value
 }
+
+// parseLine parses a line answer into the confirmation value.
+// It accepts "y", "yes", "true" or the active label as true, and "n", "no", "false" or the inactive label as false.
+// An empty answer keeps the current value.
+//
+// Parameters:
+//   - line (string): The line answer to parse.
+//
+// Returns:
+//   - bool: The parsed value.
+//   - error: An error if the answer is not recognized.
+func (p *ConfirmPrompt) parseLine(line string) (bool, error) {
+	answer := strings.TrimSpace(line)
+	switch {
+	case answer == "":
+		return p.Value, nil
+	case strings.EqualFold(answer, p.Active):
+		return true, nil
+	case strings.EqualFold(answer, p.Inactive):
+		return false, nil
+	}
+
+	switch strings.ToLower(answer) {
+	case "y", "yes", "true":
+		return true, nil
+	case "n", "no", "false":
+		return false, nil
+	}
+
+	return p.Value, fmt.Errorf("Please answer %s or %s.", p.Active, p.Inactive)
+}
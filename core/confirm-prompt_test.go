@@ -1,6 +1,8 @@
 package core_test
 
 import (
+	"bytes"
+	"strings"
 	"testing"
 
 	"github.com/orochaa/go-clack/core"
@@ -33,3 +35,36 @@ func TestChangeConfirmValue(t *testing.T) {
 	assert.Equal(t, false, p.Value)
 	assert.Equal(t, 0, p.CursorIndex)
 }
+
+func TestConfirmPromptLineMode(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected bool
+		isValid  bool
+	}{
+		{input: "y", expected: true, isValid: true},
+		{input: "YES", expected: true, isValid: true},
+		{input: "no", expected: false, isValid: true},
+		{input: "", expected: true, isValid: true},
+		{input: "maybe", isValid: false},
+	}
+
+	for _, tC := range testCases {
+		p := core.NewConfirmPrompt(core.ConfirmPromptParams{
+			Input:        strings.NewReader(tC.input + "\n"),
+			Output:       &bytes.Buffer{},
+			InitialValue: true,
+			Render:       func(p *core.ConfirmPrompt) string { return "" },
+		})
+		p.LineMode = true
+
+		value, err := p.Run()
+		if tC.isValid {
+			assert.NoError(t, err)
+			assert.Equal(t, tC.expected, value)
+		} else {
+			assert.Error(t, err)
+			assert.Equal(t, "Please answer yes or no.", p.Error)
+		}
+	}
+}
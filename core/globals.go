@@ -8,6 +8,8 @@ import (
 
 var (
 	ErrCancelPrompt error = errors.New("prompt canceled")
+	ErrTimeout      error = errors.New("prompt timed out")
+	ErrNoTerminal   error = errors.New("output is not a terminal")
 )
 
 type FileSystem interface {
@@ -16,6 +18,27 @@ type FileSystem interface {
 	UserHomeDir() (string, error)
 }
 
+// StatFileSystem is an optional capability of a FileSystem.
+// File systems implementing it are asked for the information of a single path, e.g. to check a path answered in line mode,
+// instead of reading its parent directory.
+type StatFileSystem interface {
+	Stat(name string) (os.FileInfo, error)
+}
+
+// RawTerminal is an optional capability of a prompt's Input.
+// Inputs implementing it are switched into raw mode while the prompt runs, so keys are read one at a time.
+// If IsTerminal reports false, the prompt falls back to line mode instead.
+type RawTerminal interface {
+	IsTerminal() bool
+	MakeRaw() (restore func() error, err error)
+}
+
+// SizedTerminal is an optional capability of a prompt's Output.
+// Outputs implementing it report their dimensions, which are used to wrap and limit the rendered lines.
+type SizedTerminal interface {
+	Size() (width int, height int, err error)
+}
+
 // WrapRender wraps a render function for a specific prompt type (TPrompt) into a function compatible with the Prompt[T] type.
 // It allows custom rendering logic to be applied to a prompt.
 //
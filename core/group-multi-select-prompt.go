@@ -3,7 +3,9 @@ package core
 import (
 	"context"
 	"fmt"
-	"os"
+	"io"
+	"slices"
+	"time"
 
 	"github.com/orochaa/go-clack/core/utils"
 	"github.com/orochaa/go-clack/core/validator"
@@ -40,13 +42,20 @@ type GroupMultiSelectPrompt[TValue comparable] struct {
 
 type GroupMultiSelectPromptParams[TValue comparable] struct {
 	Context        context.Context
-	Input          *os.File
-	Output         *os.File
+	Input          io.Reader
+	Output         io.Writer
 	Options        map[string][]MultiSelectOption[TValue]
 	InitialValue   []TValue
 	DisabledGroups bool
 	Required       bool
 	Validate       func(value []TValue) error
+	Mouse          bool
+	Timeout        time.Duration
+	TimeoutCancel  bool
+	Ephemeral      bool
+	LineMode       bool
+	Summary        func(value []TValue) string
+	Settings       *SettingsOptions
 	Render         func(p *GroupMultiSelectPrompt[TValue]) string
 }
 
@@ -60,13 +69,20 @@ type GroupMultiSelectPromptParams[TValue comparable] struct {
 //
 // Parameters:
 //   - Context (context.Context): The context for the prompt (default: context.Background).
-//   - Input (*os.File): The input stream for the prompt (default: OSFileSystem).
-//   - Output (*os.File): The output stream for the prompt (default: OSFileSystem).
+//   - Input (io.Reader): The input stream for the prompt (default: os.Stdin).
+//   - Output (io.Writer): The output stream for the prompt (default: os.Stdout).
 //   - Options (map[string][]MultiSelectOption[TValue]): A map of grouped options for the prompt (default: nil.
 //   - InitialValue ([]TValue): The initial selected values (default: nil.
 //   - DisabledGroups (bool): Whether groups are disabled for selection (default: false).
 //   - Required (bool): Whether the prompt requires at least one selection (default: false).
 //   - Validate (func(value []TValue) error): Custom validation function for the prompt (default: nil).
+//   - Mouse (bool): Whether to select options by clicking and scroll them with the wheel (default: Settings.Mouse).
+//   - Timeout (time.Duration): The time after which the current value is submitted, unless the user presses a key before (default: 0, no timeout).
+//   - TimeoutCancel (bool): Whether to cancel the prompt with ErrTimeout instead of submitting the current value on timeout (default: false).
+//   - Ephemeral (bool): Whether to erase the prompt once submitted, or replace it by its Summary (default: false).
+//   - LineMode (bool): Whether to read whole lines as answers, without raw mode or cursor movements, as done when the input is not a terminal (default: false).
+//   - Summary (func(value []TValue) string): The one-line summary of the submitted value of an ephemeral prompt (default: nil, the prompt is erased).
+//   - Settings (*SettingsOptions): The key bindings and options of the prompt (default: the global Settings).
 //   - Render (func(p *GroupMultiSelectPrompt[TValue]) string): Custom render function for the prompt (default: nil).
 //
 // Returns:
@@ -81,12 +97,20 @@ func NewGroupMultiSelectPrompt[TValue comparable](params GroupMultiSelectPromptP
 	var p GroupMultiSelectPrompt[TValue]
 	p = GroupMultiSelectPrompt[TValue]{
 		Prompt: *NewPrompt(PromptParams[[]TValue]{
-			Context:      params.Context,
-			Input:        params.Input,
-			Output:       params.Output,
-			InitialValue: mapGroupMultiSelectInitialValue(params.InitialValue, options),
-			Validate:     WrapValidate(params.Validate, &p.Required, "Please select at least one option. Press `space` to select"),
-			Render:       WrapRender[[]TValue](&p, params.Render),
+			Context:       params.Context,
+			Input:         params.Input,
+			Output:        params.Output,
+			InitialValue:  mapGroupMultiSelectInitialValue(params.InitialValue, options),
+			Validate:      WrapValidate(params.Validate, &p.Required, "Please select at least one option. Press `space` to select"),
+			ParseLine:     p.parseLine,
+			Mouse:         params.Mouse || resolveSettings(params.Settings).Mouse,
+			Timeout:       params.Timeout,
+			TimeoutCancel: params.TimeoutCancel,
+			Ephemeral:     params.Ephemeral,
+			LineMode:      params.LineMode,
+			Summary:       params.Summary,
+			Settings:      params.Settings,
+			Render:        WrapRender[[]TValue](&p, params.Render),
 		}),
 		Options:        options,
 		DisabledGroups: params.DisabledGroups,
@@ -97,17 +121,34 @@ func NewGroupMultiSelectPrompt[TValue comparable](params GroupMultiSelectPromptP
 		p.CursorIndex = 1
 	}
 
-	actionHandler := NewActionHandler(map[Action]func(){
-		UpAction:    func() { p.moveCursor(-1) },
-		DownAction:  func() { p.moveCursor(1) },
-		LeftAction:  func() { p.moveCursor(-1) },
-		RightAction: func() { p.moveCursor(1) },
-		HomeAction:  func() { p.CursorIndex = 0 },
-		EndAction:   func() { p.CursorIndex = len(p.Options) - 1 },
-		SpaceAction: p.toggleOption,
+	actionHandler := p.NewActionHandler(map[Action]func(){
+		UpAction:           func() { p.moveCursor(-1) },
+		DownAction:         func() { p.moveCursor(1) },
+		LeftAction:         func() { p.moveCursor(-1) },
+		RightAction:        func() { p.moveCursor(1) },
+		HomeAction:         func() { p.CursorIndex = 0 },
+		EndAction:          func() { p.CursorIndex = len(p.Options) - 1 },
+		SpaceAction:        p.toggleOption,
+		HalfPageUpAction:   func() { p.movePage(-p.halfPage()) },
+		HalfPageDownAction: func() { p.movePage(p.halfPage()) },
+		PageUpAction:       func() { p.movePage(-p.pageSize()) },
+		PageDownAction:     func() { p.movePage(p.pageSize()) },
 	}, nil)
-	p.On(KeyEvent, func(args ...any) {
-		actionHandler(args[0].(*Key))
+	p.typesKeys = func() bool { return false }
+	p.OnKey(actionHandler)
+	p.OnMouse(func(mouse *Mouse) {
+		p.handleMouse(mouse, p.moveCursor, func(index int) {
+			if index >= len(p.Options) || (p.DisabledGroups && p.Options[index].IsGroup) {
+				return
+			}
+			p.CursorIndex = index
+			p.toggleOption()
+		})
+	})
+	p.OnSubmit(func(value []TValue) {
+		if p.LineMode {
+			p.selectOptions(value)
+		}
 	})
 
 	return &p
@@ -146,6 +187,18 @@ func (p *GroupMultiSelectPrompt[TValue]) moveCursor(direction int) {
 	}
 }
 
+// movePage moves the cursor by a number of options, stopping at the first and last options.
+// If groups are disabled, a group header is skipped to its first option.
+//
+// Parameters:
+//   - offset (int): The number of options to move the cursor by, negative to move it up.
+func (p *GroupMultiSelectPrompt[TValue]) movePage(offset int) {
+	p.CursorIndex = clampIndex(p.CursorIndex, offset, len(p.Options))
+	if p.DisabledGroups && p.Options[p.CursorIndex].IsGroup {
+		p.CursorIndex = clampIndex(p.CursorIndex, 1, len(p.Options))
+	}
+}
+
 // toggleOption toggles the selection state of the currently highlighted option or group.
 // If a group is selected, it toggles all options within the group.
 // If an option is selected, it updates the prompt's value accordingly.
@@ -251,3 +304,64 @@ func mapGroupMultiSelectInitialValue[TValue comparable](value []TValue, options
 	}
 	return initialValue
 }
+
+// parseLine parses a comma-separated line answer into the values of the matching options.
+// Each item may be an option's label or value; a group's label selects all of its options, unless groups are disabled.
+// An empty answer keeps the current selection.
+//
+// Parameters:
+//   - line (string): The line answer to parse.
+//
+// Returns:
+//   - []TValue: The values of the matching options.
+//   - error: An error if an item does not match any option.
+func (p *GroupMultiSelectPrompt[TValue]) parseLine(line string) ([]TValue, error) {
+	items := splitLineList(line)
+	if len(items) == 0 {
+		return p.Value, nil
+	}
+
+	labels := make([]string, len(p.Options))
+	values := make([]string, len(p.Options))
+	for i, option := range p.Options {
+		labels[i] = option.Label
+		if !option.IsGroup {
+			values[i] = fmt.Sprint(option.Value)
+		}
+	}
+
+	selected := make(map[*GroupMultiSelectOption[TValue]]bool)
+	for _, item := range items {
+		index := matchLineOption(item, labels, values)
+		if index == -1 || (p.DisabledGroups && p.Options[index].IsGroup) {
+			return p.Value, fmt.Errorf("Unknown option: %s", item)
+		}
+
+		option := p.Options[index]
+		if option.IsGroup {
+			for _, groupOption := range option.Options {
+				selected[groupOption] = true
+			}
+			continue
+		}
+		selected[option] = true
+	}
+
+	value := []TValue{}
+	for _, option := range p.Options {
+		if !option.IsGroup && selected[option] {
+			value = append(value, option.Value)
+		}
+	}
+	return value, nil
+}
+
+// selectOptions selects the options of the values submitted in line mode, and deselects the others.
+// Options are only selected once the answer is validated, so they stay in sync with the value of the prompt.
+func (p *GroupMultiSelectPrompt[TValue]) selectOptions(value []TValue) {
+	for _, option := range p.Options {
+		if !option.IsGroup {
+			option.IsSelected = slices.Contains(value, option.Value)
+		}
+	}
+}
@@ -1,6 +1,8 @@
 package core_test
 
 import (
+	"bytes"
+	"strings"
 	"testing"
 
 	"github.com/orochaa/go-clack/core"
@@ -222,4 +224,26 @@ func TestGroupMultiSelectDisabledGroups(t *testing.T) {
 	assert.Equal(t, 1, p.CursorIndex)
 	p.PressKey(&core.Key{Name: core.UpKey})
 	assert.Equal(t, 5, p.CursorIndex)
+
+	p.PressKey(&core.Key{Name: core.PageUpKey})
+	assert.Equal(t, 1, p.CursorIndex)
+	p.PressKey(&core.Key{Name: core.PageDownKey})
+	assert.Equal(t, 5, p.CursorIndex)
+}
+
+func TestGroupMultiSelectPromptLineMode(t *testing.T) {
+	p := core.NewGroupMultiSelectPrompt(core.GroupMultiSelectPromptParams[string]{
+		Input:  strings.NewReader("g1, y\n"),
+		Output: &bytes.Buffer{},
+		Options: map[string][]core.MultiSelectOption[string]{
+			"g1": {{Value: "a"}, {Value: "b"}},
+			"g2": {{Value: "x"}, {Value: "y"}},
+		},
+		Render: func(p *core.GroupMultiSelectPrompt[string]) string { return "" },
+	})
+	p.LineMode = true
+
+	value, err := p.Run()
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{"a", "b", "y"}, value)
 }
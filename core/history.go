@@ -0,0 +1,171 @@
+package core
+
+import (
+	"errors"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// DefaultHistorySize is the number of entries kept by a history store when no maximum size is set.
+const DefaultHistorySize = 1000
+
+// HistoryStore stores the previous answers of prompts, keyed by a prompt ID.
+// Entries are ordered from the oldest to the newest.
+type HistoryStore interface {
+	// Load returns the entries of a prompt ID, or nil if there are none.
+	Load(id string) ([]string, error)
+	// Add adds an entry to a prompt ID as its newest entry.
+	Add(id string, entry string) error
+}
+
+// appendHistoryEntry appends an entry to a history, removing its previous occurrences and the oldest entries over the maximum size.
+func appendHistoryEntry(entries []string, entry string, maxSize int) []string {
+	if maxSize <= 0 {
+		maxSize = DefaultHistorySize
+	}
+	entries = slices.DeleteFunc(entries, func(e string) bool { return e == entry })
+	entries = append(entries, entry)
+	if len(entries) > maxSize {
+		entries = entries[len(entries)-maxSize:]
+	}
+	return entries
+}
+
+// MemoryHistory is a HistoryStore that keeps the entries in memory, for the lifetime of the process.
+type MemoryHistory struct {
+	mu      sync.Mutex
+	entries map[string][]string
+	MaxSize int
+}
+
+// NewMemoryHistory initializes a new in-memory history store.
+//
+// Parameters:
+//   - maxSize (int): The maximum number of entries kept per prompt ID (default: DefaultHistorySize).
+//
+// Returns:
+//   - *MemoryHistory: A new instance of MemoryHistory.
+func NewMemoryHistory(maxSize int) *MemoryHistory {
+	return &MemoryHistory{
+		entries: make(map[string][]string),
+		MaxSize: maxSize,
+	}
+}
+
+// Load returns a copy of the entries of a prompt ID.
+func (h *MemoryHistory) Load(id string) ([]string, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return slices.Clone(h.entries[id]), nil
+}
+
+// Add adds an entry to a prompt ID, moving it to the end if it already exists.
+func (h *MemoryHistory) Add(id string, entry string) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.entries[id] = appendHistoryEntry(h.entries[id], entry, h.MaxSize)
+	return nil
+}
+
+// FileHistory is a HistoryStore that persists the entries in a directory, with a file per prompt ID.
+// Each entry is written in its own line, with backslashes and line breaks escaped.
+type FileHistory struct {
+	mu      sync.Mutex
+	Dir     string
+	MaxSize int
+}
+
+// NewFileHistory initializes a new file-backed history store.
+// The directory is created when the first entry is added.
+//
+// Parameters:
+//   - dir (string): The directory holding the history files.
+//   - maxSize (int): The maximum number of entries kept per prompt ID (default: DefaultHistorySize).
+//
+// Returns:
+//   - *FileHistory: A new instance of FileHistory.
+func NewFileHistory(dir string, maxSize int) *FileHistory {
+	return &FileHistory{
+		Dir:     dir,
+		MaxSize: maxSize,
+	}
+}
+
+// path returns the path of the history file of a prompt ID.
+func (h *FileHistory) path(id string) string {
+	if id == "" {
+		id = "default"
+	}
+	return filepath.Join(h.Dir, url.PathEscape(id)+".history")
+}
+
+var (
+	historyEscaper   = strings.NewReplacer(`\`, `\\`, "\n", `\n`)
+	historyUnescaper = strings.NewReplacer(`\\`, `\`, `\n`, "\n")
+)
+
+// Load reads the entries of a prompt ID from its history file.
+// A missing file is an empty history.
+func (h *FileHistory) Load(id string) ([]string, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.load(id)
+}
+
+func (h *FileHistory) load(id string) ([]string, error) {
+	data, err := os.ReadFile(h.path(id))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []string
+	for _, line := range strings.Split(string(data), "\n") {
+		if line != "" {
+			entries = append(entries, historyUnescaper.Replace(line))
+		}
+	}
+	return entries, nil
+}
+
+// Add adds an entry to a prompt ID and rewrites its history file.
+// The file is replaced at once, so a concurrent reader never sees a partial history.
+func (h *FileHistory) Add(id string, entry string) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	entries, err := h.load(id)
+	if err != nil {
+		return err
+	}
+	entries = appendHistoryEntry(entries, entry, h.MaxSize)
+
+	if err := os.MkdirAll(h.Dir, 0o755); err != nil {
+		return err
+	}
+	var data strings.Builder
+	for _, e := range entries {
+		data.WriteString(historyEscaper.Replace(e))
+		data.WriteString("\n")
+	}
+
+	file, err := os.CreateTemp(h.Dir, ".history-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(file.Name())
+	if _, err := file.WriteString(data.String()); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+	return os.Rename(file.Name(), h.path(id))
+}
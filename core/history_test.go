@@ -0,0 +1,51 @@
+package core_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/orochaa/go-clack/core"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemoryHistory(t *testing.T) {
+	h := core.NewMemoryHistory(3)
+
+	entries, err := h.Load("foo")
+	assert.NoError(t, err)
+	assert.Empty(t, entries)
+
+	for _, entry := range []string{"a", "b", "a", "c", "d"} {
+		assert.NoError(t, h.Add("foo", entry))
+	}
+	assert.NoError(t, h.Add("bar", "x"))
+
+	entries, _ = h.Load("foo")
+	assert.Equal(t, []string{"a", "c", "d"}, entries)
+	entries, _ = h.Load("bar")
+	assert.Equal(t, []string{"x"}, entries)
+}
+
+func TestFileHistory(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "history")
+	h := core.NewFileHistory(dir, 3)
+
+	entries, err := h.Load("foo")
+	assert.NoError(t, err)
+	assert.Empty(t, entries)
+
+	for _, entry := range []string{"a", "b\nc", `d\n`, "a", "e"} {
+		assert.NoError(t, h.Add("foo", entry))
+	}
+	assert.NoError(t, h.Add("foo/bar", "x"))
+
+	entries, _ = core.NewFileHistory(dir, 3).Load("foo")
+	assert.Equal(t, []string{`d\n`, "a", "e"}, entries)
+	entries, _ = h.Load("foo/bar")
+	assert.Equal(t, []string{"x"}, entries)
+
+	files, _ := os.ReadDir(dir)
+	assert.Len(t, files, 2)
+}
@@ -15,3 +15,7 @@ func (fs OSFileSystem) ReadDir(name string) ([]os.DirEntry, error) {
 func (fs OSFileSystem) UserHomeDir() (string, error) {
 	return os.UserHomeDir()
 }
+
+func (fs OSFileSystem) Stat(name string) (os.FileInfo, error) {
+	return os.Stat(name)
+}
@@ -0,0 +1,30 @@
+package internals
+
+import (
+	"os"
+
+	"golang.org/x/term"
+)
+
+type OSTerminal struct {
+	*os.File
+}
+
+func (t OSTerminal) IsTerminal() bool {
+	return term.IsTerminal(int(t.Fd()))
+}
+
+func (t OSTerminal) MakeRaw() (func() error, error) {
+	fd := int(t.Fd())
+	oldState, err := term.MakeRaw(fd)
+	if err != nil {
+		return nil, err
+	}
+	return func() error {
+		return term.Restore(fd, oldState)
+	}, nil
+}
+
+func (t OSTerminal) Size() (int, int, error) {
+	return term.GetSize(int(t.Fd()))
+}
@@ -1,6 +1,11 @@
 package core_test
 
-import "os"
+import (
+	"bytes"
+	"errors"
+	"os"
+	"strings"
+)
 
 type MockDirEntry struct {
 	name  string
@@ -42,3 +47,38 @@ func (fs MockFileSystem) ReadDir(name string) ([]os.DirEntry, error) {
 func (fs MockFileSystem) UserHomeDir() (string, error) {
 	return "/home/clack", nil
 }
+
+// MockStatFileSystem is a MockFileSystem which also reports the information of single paths.
+type MockStatFileSystem struct {
+	MockFileSystem
+}
+
+func (fs MockStatFileSystem) Stat(name string) (os.FileInfo, error) {
+	if name == "/stat" {
+		return os.Stat(os.TempDir())
+	}
+	return nil, os.ErrNotExist
+}
+
+type MockTerminal struct {
+	bytes.Buffer
+	Width  int
+	Height int
+}
+
+func (t *MockTerminal) Size() (int, int, error) {
+	return t.Width, t.Height, nil
+}
+
+// MockPipe is a RawTerminal input which is not a terminal, as a pipe.
+type MockPipe struct {
+	*strings.Reader
+}
+
+func (p MockPipe) IsTerminal() bool {
+	return false
+}
+
+func (p MockPipe) MakeRaw() (func() error, error) {
+	return nil, errors.New("not a terminal")
+}
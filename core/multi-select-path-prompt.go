@@ -2,9 +2,10 @@ package core
 
 import (
 	"context"
-	"os"
+	"io"
 	"path"
 	"sort"
+	"time"
 
 	"github.com/orochaa/go-clack/core/internals"
 	"github.com/orochaa/go-clack/core/utils"
@@ -23,17 +24,24 @@ type MultiSelectPathPrompt struct {
 }
 
 type MultiSelectPathPromptParams struct {
-	Context      context.Context
-	Input        *os.File
-	Output       *os.File
-	InitialValue []string
-	InitialPath  string
-	OnlyShowDir  bool
-	Required     bool
-	Filter       bool
-	FileSystem   FileSystem
-	Validate     func(value []string) error
-	Render       func(p *MultiSelectPathPrompt) string
+	Context       context.Context
+	Input         io.Reader
+	Output        io.Writer
+	InitialValue  []string
+	InitialPath   string
+	OnlyShowDir   bool
+	Required      bool
+	Filter        bool
+	FileSystem    FileSystem
+	Validate      func(value []string) error
+	Mouse         bool
+	Timeout       time.Duration
+	TimeoutCancel bool
+	Ephemeral     bool
+	LineMode      bool
+	Summary       func(value []string) string
+	Settings      *SettingsOptions
+	Render        func(p *MultiSelectPathPrompt) string
 }
 
 // NewMultiSelectPathPrompt initializes and returns a new instance of MultiSelectPathPrompt.
@@ -46,8 +54,8 @@ type MultiSelectPathPromptParams struct {
 //
 // Parameters:
 //   - Context (context.Context): The context for the prompt (default: context.Background).
-//   - Input (*os.File): The input stream for the prompt (default: OSFileSystem).
-//   - Output (*os.File): The output stream for the prompt (default: OSFileSystem).
+//   - Input (io.Reader): The input stream for the prompt (default: os.Stdin).
+//   - Output (io.Writer): The output stream for the prompt (default: os.Stdout).
 //   - InitialValue ([]string): Initial selected paths (default: nil).
 //   - InitialPath (string): The initial directory path to start from (default: current working directory).
 //   - OnlyShowDir (bool): Whether to only show directories (default: false).
@@ -55,6 +63,13 @@ type MultiSelectPathPromptParams struct {
 //   - Filter (bool): Whether to enable filtering of options (default: false).
 //   - FileSystem (FileSystem): The file system implementation to use (default: OSFileSystem).
 //   - Validate (func(value []string) error): Custom validation function (default: nil).
+//   - Mouse (bool): Whether to select options by clicking and scroll them with the wheel (default: Settings.Mouse).
+//   - Timeout (time.Duration): The time after which the current value is submitted, unless the user presses a key before (default: 0, no timeout).
+//   - TimeoutCancel (bool): Whether to cancel the prompt with ErrTimeout instead of submitting the current value on timeout (default: false).
+//   - Ephemeral (bool): Whether to erase the prompt once submitted, or replace it by its Summary (default: false).
+//   - LineMode (bool): Whether to read whole lines as answers, without raw mode or cursor movements, as done when the input is not a terminal (default: false).
+//   - Summary (func(value []string) string): The one-line summary of the submitted value of an ephemeral prompt (default: nil, the prompt is erased).
+//   - Settings (*SettingsOptions): The key bindings and options of the prompt (default: the global Settings).
 //   - Render (func(p *MultiSelectPathPrompt) string): Custom render function (default: nil).
 //
 // Returns:
@@ -70,13 +85,21 @@ func NewMultiSelectPathPrompt(params MultiSelectPathPromptParams) *MultiSelectPa
 	var p MultiSelectPathPrompt
 	p = MultiSelectPathPrompt{
 		Prompt: *NewPrompt(PromptParams[[]string]{
-			Context:      params.Context,
-			Input:        params.Input,
-			Output:       params.Output,
-			InitialValue: params.InitialValue,
-			CursorIndex:  1,
-			Validate:     WrapValidate(params.Validate, &p.Required, "Please select at least one option. Press `space` to select"),
-			Render:       WrapRender[[]string](&p, params.Render),
+			Context:       params.Context,
+			Input:         params.Input,
+			Output:        params.Output,
+			InitialValue:  params.InitialValue,
+			CursorIndex:   1,
+			Validate:      WrapValidate(params.Validate, &p.Required, "Please select at least one option. Press `space` to select"),
+			ParseLine:     p.parseLine,
+			Mouse:         params.Mouse || resolveSettings(params.Settings).Mouse,
+			Timeout:       params.Timeout,
+			TimeoutCancel: params.TimeoutCancel,
+			Ephemeral:     params.Ephemeral,
+			LineMode:      params.LineMode,
+			Summary:       params.Summary,
+			Settings:      params.Settings,
+			Render:        WrapRender[[]string](&p, params.Render),
 		}),
 		OnlyShowDir: params.OnlyShowDir,
 		Filter:      params.Filter,
@@ -94,7 +117,7 @@ func NewMultiSelectPathPrompt(params MultiSelectPathPromptParams) *MultiSelectPa
 	p.CurrentOption = p.Root.FirstChild()
 	p.mapSelectedOptions(p.Root)
 
-	actionHandler := NewActionHandler(map[Action]func(){
+	actionHandler := p.NewActionHandler(map[Action]func(){
 		UpAction:    func() { p.moveCursor(-1) },
 		DownAction:  func() { p.moveCursor(1) },
 		LeftAction:  p.closeNode,
@@ -111,13 +134,25 @@ func NewMultiSelectPathPrompt(params MultiSelectPathPromptParams) *MultiSelectPa
 				p.CursorIndex = p.Root.IndexOf(p.CurrentOption, p.Options())
 			}
 		},
-		SpaceAction: p.toggleOption,
+		SpaceAction:        p.toggleOption,
+		HalfPageUpAction:   func() { p.movePage(-p.halfPage()) },
+		HalfPageDownAction: func() { p.movePage(p.halfPage()) },
+		PageUpAction:       func() { p.movePage(-p.pageSize()) },
+		PageDownAction:     func() { p.movePage(p.pageSize()) },
 	}, p.filterOptions)
-	p.On(KeyEvent, func(args ...any) {
-		actionHandler(args[0].(*Key))
+	p.typesKeys = func() bool { return p.Filter }
+	p.OnKey(actionHandler)
+	p.OnMouse(func(mouse *Mouse) {
+		p.handleMouse(mouse, p.moveCursor, func(index int) {
+			if options := p.Options(); index < len(options) {
+				p.CurrentOption = options[index]
+				p.CursorIndex = index
+				p.toggleOption()
+			}
+		})
 	})
 
-	p.On(FinalizeEvent, func(args ...any) {
+	p.OnFinalize(func() {
 		sort.SliceStable(p.Value, func(i, j int) bool {
 			return p.Value[i] < p.Value[j]
 		})
@@ -146,6 +181,18 @@ func (p *MultiSelectPathPrompt) moveCursor(direction int) {
 	}
 }
 
+// movePage moves the cursor by a number of options within the current layer, stopping at its first and last options.
+//
+// Parameters:
+//   - offset (int): The number of options to move the cursor by, negative to move it up.
+func (p *MultiSelectPathPrompt) movePage(offset int) {
+	if layerOptions := p.CurrentOption.FilteredLayer(p.Search); len(layerOptions) > 0 {
+		layerIndex := p.Root.IndexOf(p.CurrentOption, layerOptions)
+		p.CurrentOption = layerOptions[clampIndex(layerIndex, offset, len(layerOptions))]
+		p.CursorIndex = p.Root.IndexOf(p.CurrentOption, p.Options())
+	}
+}
+
 // closeNode closes the currently selected node or moves up to the parent directory.
 func (p *MultiSelectPathPrompt) closeNode() {
 	p.Search = ""
@@ -210,7 +257,7 @@ func (p *MultiSelectPathPrompt) filterOptions(key *Key) {
 		return
 	}
 
-	p.Search, _ = p.TrackKeyValue(key, p.Search, len(p.Search))
+	p.Search, _ = p.TrackKeyValue(key, p.Search, utils.GraphemeCount(p.Search))
 	if p.CurrentOption.IsRoot() {
 		return
 	}
@@ -240,3 +287,25 @@ func (p *MultiSelectPathPrompt) mapSelectedOptions(node *PathNode) {
 		node.IsSelected = false
 	})
 }
+
+// parseLine parses a comma-separated line answer into the selected paths.
+// An empty answer keeps the current selection, while paths which do not exist, or are not directories if OnlyShowDir is set, are rejected.
+//
+// Parameters:
+//   - line (string): The line answer to parse.
+//
+// Returns:
+//   - []string: The selected paths.
+//   - error: An error if a path does not exist, nil otherwise.
+func (p *MultiSelectPathPrompt) parseLine(line string) ([]string, error) {
+	items := splitLineList(line)
+	if len(items) == 0 {
+		return p.Value, nil
+	}
+	for _, item := range items {
+		if err := checkLinePath(p.FileSystem, item, p.OnlyShowDir); err != nil {
+			return p.Value, err
+		}
+	}
+	return items, nil
+}
@@ -1,7 +1,10 @@
 package core_test
 
 import (
+	"bytes"
+	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/orochaa/go-clack/core"
@@ -197,3 +200,60 @@ func TestMultiSelectPathSortOnFinalize(t *testing.T) {
 
 	assert.Equal(t, []string{"1", "a", "b"}, p.Value)
 }
+
+func TestMultiSelectPathLineModeWithFile(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "file")
+	assert.NoError(t, os.WriteFile(file, nil, 0o644))
+	p := core.NewMultiSelectPathPrompt(core.MultiSelectPathPromptParams{
+		Input:       strings.NewReader(dir + ", " + file + "\n" + dir + "\n"),
+		Output:      &bytes.Buffer{},
+		OnlyShowDir: true,
+		Render:      func(p *core.MultiSelectPathPrompt) string { return "" },
+	})
+	p.LineMode = true
+
+	var errorMessage string
+	p.On(core.ErrorEvent, func(args ...any) { errorMessage = args[0].(error).Error() })
+
+	value, err := p.Run()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{dir}, value)
+	assert.Equal(t, "Path is not a directory: "+file, errorMessage)
+}
+
+func TestMultiSelectPathLineModeWithFileSystem(t *testing.T) {
+	p := core.NewMultiSelectPathPrompt(core.MultiSelectPathPromptParams{
+		Input:      strings.NewReader("/clack/foo\n/clack/dir, /clack/file\n"),
+		Output:     &bytes.Buffer{},
+		FileSystem: MockFileSystem{},
+		Render:     func(p *core.MultiSelectPathPrompt) string { return "" },
+	})
+	p.LineMode = true
+
+	var errorMessage string
+	p.On(core.ErrorEvent, func(args ...any) { errorMessage = args[0].(error).Error() })
+
+	value, err := p.Run()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"/clack/dir", "/clack/file"}, value)
+	assert.Equal(t, "Path does not exist: /clack/foo", errorMessage)
+}
+
+func TestMultiSelectPathLineModeWithStatFileSystem(t *testing.T) {
+	p := core.NewMultiSelectPathPrompt(core.MultiSelectPathPromptParams{
+		Input:      strings.NewReader("/clack/dir\n/stat\n"),
+		Output:     &bytes.Buffer{},
+		FileSystem: MockStatFileSystem{},
+		Render:     func(p *core.MultiSelectPathPrompt) string { return "" },
+	})
+	p.LineMode = true
+
+	var errorMessage string
+	p.On(core.ErrorEvent, func(args ...any) { errorMessage = args[0].(error).Error() })
+
+	value, err := p.Run()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"/stat"}, value)
+	assert.Equal(t, "Path does not exist: /clack/dir", errorMessage)
+}
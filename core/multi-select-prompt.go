@@ -3,8 +3,10 @@ package core
 import (
 	"context"
 	"fmt"
-	"os"
+	"io"
 	"regexp"
+	"slices"
+	"time"
 
 	"github.com/orochaa/go-clack/core/utils"
 	"github.com/orochaa/go-clack/core/validator"
@@ -41,15 +43,22 @@ type MultiSelectPrompt[TValue comparable] struct {
 }
 
 type MultiSelectPromptParams[TValue comparable] struct {
-	Context      context.Context
-	Input        *os.File
-	Output       *os.File
-	Options      []*MultiSelectOption[TValue]
-	InitialValue []TValue
-	Filter       bool
-	Required     bool
-	Validate     func(value []TValue) error
-	Render       func(p *MultiSelectPrompt[TValue]) string
+	Context       context.Context
+	Input         io.Reader
+	Output        io.Writer
+	Options       []*MultiSelectOption[TValue]
+	InitialValue  []TValue
+	Filter        bool
+	Required      bool
+	Validate      func(value []TValue) error
+	Mouse         bool
+	Timeout       time.Duration
+	TimeoutCancel bool
+	Ephemeral     bool
+	LineMode      bool
+	Summary       func(value []TValue) string
+	Settings      *SettingsOptions
+	Render        func(p *MultiSelectPrompt[TValue]) string
 }
 
 // NewMultiSelectPrompt initializes and returns a new instance of MultiSelectPrompt.
@@ -62,13 +71,20 @@ type MultiSelectPromptParams[TValue comparable] struct {
 //
 // Parameters:
 //   - Context (context.Context): The context for the prompt (default: context.Background).
-//   - Input (*os.File): The input stream for the prompt (default: OSFileSystem).
-//   - Output (*os.File): The output stream for the prompt (default: OSFileSystem).
+//   - Input (io.Reader): The input stream for the prompt (default: os.Stdin).
+//   - Output (io.Writer): The output stream for the prompt (default: os.Stdout).
 //   - Options ([]*MultiSelectOption[TValue]): A list of options for the prompt (default: nil.
 //   - InitialValue ([]TValue): The initial selected values (default: nil.
 //   - Filter (bool): Whether to enable filtering of options (default: false).
 //   - Required (bool): Whether the prompt requires at least one selection (default: false).
 //   - Validate (func(value []TValue) error): Custom validation function for the prompt (default: nil).
+//   - Mouse (bool): Whether to select options by clicking and scroll them with the wheel (default: Settings.Mouse).
+//   - Timeout (time.Duration): The time after which the current value is submitted, unless the user presses a key before (default: 0, no timeout).
+//   - TimeoutCancel (bool): Whether to cancel the prompt with ErrTimeout instead of submitting the current value on timeout (default: false).
+//   - Ephemeral (bool): Whether to erase the prompt once submitted, or replace it by its Summary (default: false).
+//   - LineMode (bool): Whether to read whole lines as answers, without raw mode or cursor movements, as done when the input is not a terminal (default: false).
+//   - Summary (func(value []TValue) string): The one-line summary of the submitted value of an ephemeral prompt (default: nil, the prompt is erased).
+//   - Settings (*SettingsOptions): The key bindings and options of the prompt (default: the global Settings).
 //   - Render (func(p *MultiSelectPrompt[TValue]) string): Custom render function for the prompt (default: nil).
 //
 // Returns:
@@ -87,12 +103,20 @@ func NewMultiSelectPrompt[TValue comparable](params MultiSelectPromptParams[TVal
 	var p MultiSelectPrompt[TValue]
 	p = MultiSelectPrompt[TValue]{
 		Prompt: *NewPrompt(PromptParams[[]TValue]{
-			Context:      params.Context,
-			Input:        params.Input,
-			Output:       params.Output,
-			InitialValue: mapMultiSelectInitialValue(params.InitialValue, params.Options),
-			Validate:     WrapValidate(params.Validate, &p.Required, "Please select at least one option. Press `space` to select"),
-			Render:       WrapRender[[]TValue](&p, params.Render),
+			Context:       params.Context,
+			Input:         params.Input,
+			Output:        params.Output,
+			InitialValue:  mapMultiSelectInitialValue(params.InitialValue, params.Options),
+			Validate:      WrapValidate(params.Validate, &p.Required, "Please select at least one option. Press `space` to select"),
+			ParseLine:     p.parseLine,
+			Mouse:         params.Mouse || resolveSettings(params.Settings).Mouse,
+			Timeout:       params.Timeout,
+			TimeoutCancel: params.TimeoutCancel,
+			Ephemeral:     params.Ephemeral,
+			LineMode:      params.LineMode,
+			Summary:       params.Summary,
+			Settings:      params.Settings,
+			Render:        WrapRender[[]TValue](&p, params.Render),
 		}),
 		initialOptions: params.Options,
 		Options:        params.Options,
@@ -100,23 +124,39 @@ func NewMultiSelectPrompt[TValue comparable](params MultiSelectPromptParams[TVal
 		Required:       params.Required,
 	}
 
-	actionHandler := NewActionHandler(map[Action]func(){
-		UpAction:    func() { p.moveCursor(-1) },
-		DownAction:  func() { p.moveCursor(1) },
-		LeftAction:  func() { p.moveCursor(-1) },
-		RightAction: func() { p.moveCursor(1) },
-		HomeAction:  func() { p.CursorIndex = 0 },
-		EndAction:   func() { p.CursorIndex = len(p.Options) - 1 },
-		SpaceAction: p.toggleOption,
+	actionHandler := p.NewActionHandler(map[Action]func(){
+		UpAction:           func() { p.moveCursor(-1) },
+		DownAction:         func() { p.moveCursor(1) },
+		LeftAction:         func() { p.moveCursor(-1) },
+		RightAction:        func() { p.moveCursor(1) },
+		HomeAction:         func() { p.CursorIndex = 0 },
+		EndAction:          func() { p.CursorIndex = len(p.Options) - 1 },
+		SpaceAction:        p.toggleOption,
+		HalfPageUpAction:   func() { p.movePage(-p.halfPage()) },
+		HalfPageDownAction: func() { p.movePage(p.halfPage()) },
+		PageUpAction:       func() { p.movePage(-p.pageSize()) },
+		PageDownAction:     func() { p.movePage(p.pageSize()) },
 	}, func(key *Key) {
 		if p.Filter {
 			p.filterOptions(key)
-		} else if key.Name == "a" {
+		} else if key.Binding() == "a" {
 			p.toggleAllOptions()
 		}
 	})
-	p.On(KeyEvent, func(args ...any) {
-		actionHandler(args[0].(*Key))
+	p.typesKeys = func() bool { return p.Filter }
+	p.OnKey(actionHandler)
+	p.OnMouse(func(mouse *Mouse) {
+		p.handleMouse(mouse, p.moveCursor, func(index int) {
+			if index < len(p.Options) {
+				p.CursorIndex = index
+				p.toggleOption()
+			}
+		})
+	})
+	p.OnSubmit(func(value []TValue) {
+		if p.LineMode {
+			p.selectOptions(value)
+		}
 	})
 
 	return &p
@@ -131,6 +171,14 @@ func (p *MultiSelectPrompt[TValue]) moveCursor(direction int) {
 	p.CursorIndex = utils.MinMaxIndex(p.CursorIndex+direction, len(p.Options))
 }
 
+// movePage moves the cursor by a number of options, stopping at the first and last options.
+//
+// Parameters:
+//   - offset (int): The number of options to move the cursor by, negative to move it up.
+func (p *MultiSelectPrompt[TValue]) movePage(offset int) {
+	p.CursorIndex = clampIndex(p.CursorIndex, offset, len(p.Options))
+}
+
 // toggleOption toggles the selection state of the currently highlighted option.
 // If the option is selected, it is deselected, and vice versa.
 // The prompt's value is updated accordingly.
@@ -188,7 +236,7 @@ func (p *MultiSelectPrompt[TValue]) filterOptions(key *Key) {
 		currentOption = p.Options[p.CursorIndex]
 	}
 
-	p.Search, _ = p.TrackKeyValue(key, p.Search, len(p.Search))
+	p.Search, _ = p.TrackKeyValue(key, p.Search, utils.GraphemeCount(p.Search))
 	p.CursorIndex = 0
 
 	if p.Search == "" {
@@ -251,3 +299,52 @@ func mapMultiSelectInitialValue[TValue comparable](value []TValue, options []*Mu
 	}
 	return initialValue
 }
+
+// parseLine parses a comma-separated line answer into the values of the matching options.
+// Each item may be an option's label, its value or its 1-based position.
+// An empty answer keeps the current selection.
+//
+// Parameters:
+//   - line (string): The line answer to parse.
+//
+// Returns:
+//   - []TValue: The values of the matching options.
+//   - error: An error if an item does not match any option.
+func (p *MultiSelectPrompt[TValue]) parseLine(line string) ([]TValue, error) {
+	items := splitLineList(line)
+	if len(items) == 0 {
+		return p.Value, nil
+	}
+
+	labels := make([]string, len(p.Options))
+	values := make([]string, len(p.Options))
+	for i, option := range p.Options {
+		labels[i] = option.Label
+		values[i] = fmt.Sprint(option.Value)
+	}
+
+	selected := make([]bool, len(p.Options))
+	for _, item := range items {
+		index := matchLineOption(item, labels, values)
+		if index == -1 {
+			return p.Value, fmt.Errorf("Unknown option: %s", item)
+		}
+		selected[index] = true
+	}
+
+	value := []TValue{}
+	for i, option := range p.Options {
+		if selected[i] {
+			value = append(value, option.Value)
+		}
+	}
+	return value, nil
+}
+
+// selectOptions selects the options of the values submitted in line mode, and deselects the others.
+// Options are only selected once the answer is validated, so they stay in sync with the value of the prompt.
+func (p *MultiSelectPrompt[TValue]) selectOptions(value []TValue) {
+	for _, option := range p.Options {
+		option.IsSelected = slices.Contains(value, option.Value)
+	}
+}
@@ -1,6 +1,9 @@
 package core_test
 
 import (
+	"bytes"
+	"errors"
+	"strings"
 	"testing"
 
 	"github.com/orochaa/go-clack/core"
@@ -231,3 +234,89 @@ func TestMultiSelectInvalidOptionSelection(t *testing.T) {
 	assert.Equal(t, 0, len(p.Options))
 	assert.Equal(t, 0, len(p.Value))
 }
+
+func TestMultiSelectPromptLineMode(t *testing.T) {
+	p := core.NewMultiSelectPrompt(core.MultiSelectPromptParams[string]{
+		Input:  strings.NewReader("foo, 3\n"),
+		Output: &bytes.Buffer{},
+		Options: []*core.MultiSelectOption[string]{
+			{Label: "foo"},
+			{Label: "bar", IsSelected: true},
+			{Label: "baz"},
+		},
+		Render: func(p *core.MultiSelectPrompt[string]) string { return "" },
+	})
+	p.LineMode = true
+
+	value, err := p.Run()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"foo", "baz"}, value)
+	assert.Equal(t, true, p.Options[0].IsSelected)
+	assert.Equal(t, false, p.Options[1].IsSelected)
+	assert.Equal(t, true, p.Options[2].IsSelected)
+}
+
+func TestMultiSelectPromptLineModeWithInvalidAnswer(t *testing.T) {
+	p := core.NewMultiSelectPrompt(core.MultiSelectPromptParams[string]{
+		Input:  strings.NewReader("foo, bar\n"),
+		Output: &bytes.Buffer{},
+		Options: []*core.MultiSelectOption[string]{
+			{Label: "foo"},
+			{Label: "bar", IsSelected: true},
+		},
+		Validate: func(value []string) error { return errors.New("invalid") },
+		Render:   func(p *core.MultiSelectPrompt[string]) string { return "" },
+	})
+	p.LineMode = true
+
+	_, err := p.Run()
+	assert.ErrorIs(t, err, core.ErrCancelPrompt)
+	assert.Equal(t, []string{"bar"}, p.Value)
+	assert.Equal(t, false, p.Options[0].IsSelected)
+	assert.Equal(t, true, p.Options[1].IsSelected)
+}
+
+func TestMultiSelectPromptLineModeWithUnknownOption(t *testing.T) {
+	p := core.NewMultiSelectPrompt(core.MultiSelectPromptParams[string]{
+		Input:  strings.NewReader("foo, qux\n"),
+		Output: &bytes.Buffer{},
+		Options: []*core.MultiSelectOption[string]{
+			{Label: "foo"},
+			{Label: "bar"},
+		},
+		Render: func(p *core.MultiSelectPrompt[string]) string { return "" },
+	})
+	p.LineMode = true
+
+	var errorMessage string
+	p.On(core.ErrorEvent, func(args ...any) { errorMessage = args[0].(error).Error() })
+
+	_, err := p.Run()
+	assert.ErrorIs(t, err, core.ErrCancelPrompt)
+	assert.Equal(t, "Unknown option: qux", errorMessage)
+}
+
+func TestMultiSelectPromptMouseClick(t *testing.T) {
+	p := core.NewMultiSelectPrompt(core.MultiSelectPromptParams[string]{
+		Input:  strings.NewReader("\x1b[1;1R\x1b[<0;1;2M\x1b[<0;1;4M\x1b[<0;1;2M\x1b[<0;1;3M\r"),
+		Output: &bytes.Buffer{},
+		Mouse:  true,
+		Options: []*core.MultiSelectOption[string]{
+			{Label: "foo"},
+			{Label: "bar"},
+			{Label: "baz"},
+		},
+		Render: func(p *core.MultiSelectPrompt[string]) string {
+			lines := make([]string, len(p.Options))
+			for i, option := range p.Options {
+				lines[i] = option.Label
+			}
+			return "Select\r\n" + p.LimitLines(lines, 1)
+		},
+	})
+
+	value, err := p.Run()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"baz", "bar"}, value)
+	assert.Equal(t, 1, p.CursorIndex)
+}
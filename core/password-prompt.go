@@ -2,9 +2,11 @@ package core
 
 import (
 	"context"
-	"os"
+	"io"
 	"strings"
+	"time"
 
+	"github.com/orochaa/go-clack/core/utils"
 	"github.com/orochaa/go-clack/core/validator"
 	"github.com/orochaa/go-clack/third_party/picocolors"
 )
@@ -15,13 +17,21 @@ type PasswordPrompt struct {
 }
 
 type PasswordPromptParams struct {
-	Context      context.Context
-	Input        *os.File
-	Output       *os.File
-	InitialValue string
-	Required     bool
-	Validate     func(value string) error
-	Render       func(p *PasswordPrompt) string
+	Context           context.Context
+	Input             io.Reader
+	Output            io.Writer
+	InitialValue      string
+	Required          bool
+	Validate          func(value string) error
+	LiveValidate      func(ctx context.Context, value string) error
+	LiveValidateDelay time.Duration
+	Timeout           time.Duration
+	TimeoutCancel     bool
+	Ephemeral         bool
+	LineMode          bool
+	Summary           func(value string) string
+	Settings          *SettingsOptions
+	Render            func(p *PasswordPrompt) string
 }
 
 // NewPasswordPrompt initializes and returns a new instance of PasswordPrompt.
@@ -34,11 +44,19 @@ type PasswordPromptParams struct {
 //
 // Parameters:
 //   - Context (context.Context): The context for the prompt (default: context.Background).
-//   - Input (*os.File): The input stream for the prompt (default: OSFileSystem).
-//   - Output (*os.File): The output stream for the prompt (default: OSFileSystem).
+//   - Input (io.Reader): The input stream for the prompt (default: os.Stdin).
+//   - Output (io.Writer): The output stream for the prompt (default: os.Stdout).
 //   - InitialValue (string): The initial value of the password input (default: "").
 //   - Required (bool): Whether the password input is required (default: false).
 //   - Validate (func(value string) error): Custom validation function for the password (default: nil).
+//   - LiveValidate (func(ctx context.Context, value string) error): Validation function run as the user types, whose context is cancelled once the value changes (default: nil).
+//   - LiveValidateDelay (time.Duration): The time waited after the last keystroke before validating live (default: DefaultLiveValidateDelay).
+//   - Timeout (time.Duration): The time after which the current value is submitted, unless the user presses a key before (default: 0, no timeout).
+//   - TimeoutCancel (bool): Whether to cancel the prompt with ErrTimeout instead of submitting the current value on timeout (default: false).
+//   - Ephemeral (bool): Whether to erase the prompt once submitted, or replace it by its Summary (default: false).
+//   - LineMode (bool): Whether to read whole lines as answers, without raw mode or cursor movements, as done when the input is not a terminal (default: false).
+//   - Summary (func(value string) string): The one-line summary of the submitted value of an ephemeral prompt (default: nil, the prompt is erased).
+//   - Settings (*SettingsOptions): The key bindings and options of the prompt (default: the global Settings).
 //   - Render (func(p *PasswordPrompt) string): Custom render function for the prompt (default: nil).
 //
 // Returns:
@@ -50,31 +68,56 @@ func NewPasswordPrompt(params PasswordPromptParams) *PasswordPrompt {
 	var p PasswordPrompt
 	p = PasswordPrompt{
 		Prompt: *NewPrompt(PromptParams[string]{
-			Context:      params.Context,
-			Input:        params.Input,
-			Output:       params.Output,
-			InitialValue: params.InitialValue,
-			CursorIndex:  len(params.InitialValue),
-			Validate:     WrapValidate(params.Validate, &p.Required, "Password is required! Please enter a value."),
-			Render:       WrapRender[string](&p, params.Render),
+			Context:           params.Context,
+			Input:             params.Input,
+			Output:            params.Output,
+			InitialValue:      params.InitialValue,
+			CursorIndex:       utils.GraphemeCount(params.InitialValue),
+			Validate:          WrapValidate(params.Validate, &p.Required, "Password is required! Please enter a value."),
+			LiveValidate:      params.LiveValidate,
+			LiveValidateDelay: params.LiveValidateDelay,
+			ParseLine:         p.parseLine,
+			Timeout:           params.Timeout,
+			TimeoutCancel:     params.TimeoutCancel,
+			Ephemeral:         params.Ephemeral,
+			LineMode:          params.LineMode,
+			Summary:           params.Summary,
+			Settings:          params.Settings,
+			Render:            WrapRender[string](&p, params.Render),
 		}),
 		Required: params.Required,
 	}
 
-	p.On(KeyEvent, func(args ...any) {
-		p.Value, p.CursorIndex = p.TrackKeyValue(args[0].(*Key), p.Value, p.CursorIndex)
+	p.OnKey(func(key *Key) {
+		p.Value, p.CursorIndex = p.TrackKeyValue(key, p.Value, p.CursorIndex)
 	})
 
 	return &p
 }
 
+// parseLine parses a line answer into the password value.
+// An empty answer keeps the current value.
+//
+// Parameters:
+//   - line (string): The line answer to parse.
+//
+// Returns:
+//   - string: The parsed password.
+//   - error: Always nil.
+func (p *PasswordPrompt) parseLine(line string) (string, error) {
+	if line == "" {
+		return p.Value, nil
+	}
+	return line, nil
+}
+
 // ValueWithMask returns the current password value masked with asterisks (*).
 // This is useful for displaying the password in a secure manner.
 //
 // Returns:
 //   - string: The masked password value.
 func (p *PasswordPrompt) ValueWithMask() string {
-	return strings.Repeat("*", len(p.Value))
+	return strings.Repeat("*", utils.GraphemeCount(p.Value))
 }
 
 // ValueWithMaskAndCursor returns the current password value masked with asterisks (*) and includes a cursor indicator.
@@ -83,8 +126,9 @@ func (p *PasswordPrompt) ValueWithMask() string {
 // Returns:
 //   - string: The masked password value with the cursor indicator.
 func (p *PasswordPrompt) ValueWithMaskAndCursor() string {
-	maskedValue := strings.Repeat("*", len(p.Value))
-	if p.CursorIndex == len(p.Value) {
+	length := utils.GraphemeCount(p.Value)
+	maskedValue := strings.Repeat("*", length)
+	if p.CursorIndex >= length {
 		return maskedValue + "█"
 	}
 	return maskedValue[0:p.CursorIndex] + picocolors.Inverse(string(maskedValue[p.CursorIndex])) + maskedValue[p.CursorIndex+1:]
@@ -85,3 +85,13 @@ func TestPasswordRequiredValue(t *testing.T) {
 	p.PressKey(&core.Key{Name: core.EnterKey})
 	assert.Equal(t, core.ErrorState, p.State)
 }
+
+func TestPasswordPromptMaskGraphemes(t *testing.T) {
+	p := core.NewPasswordPrompt(core.PasswordPromptParams{
+		InitialValue: "日本👍🏽",
+		Render:       func(p *core.PasswordPrompt) string { return "" },
+	})
+
+	assert.Equal(t, "***", p.ValueWithMask())
+	assert.Equal(t, "***█", p.ValueWithMaskAndCursor())
+}
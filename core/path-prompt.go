@@ -2,9 +2,10 @@ package core
 
 import (
 	"context"
-	"os"
+	"io"
 	"regexp"
 	"strings"
+	"time"
 
 	"github.com/orochaa/go-clack/core/internals"
 	"github.com/orochaa/go-clack/core/utils"
@@ -23,15 +24,23 @@ type PathPrompt struct {
 }
 
 type PathPromptParams struct {
-	Context      context.Context
-	Input        *os.File
-	Output       *os.File
-	InitialValue string
-	OnlyShowDir  bool
-	Required     bool
-	FileSystem   FileSystem
-	Validate     func(value string) error
-	Render       func(p *PathPrompt) string
+	Context           context.Context
+	Input             io.Reader
+	Output            io.Writer
+	InitialValue      string
+	OnlyShowDir       bool
+	Required          bool
+	FileSystem        FileSystem
+	Validate          func(value string) error
+	LiveValidate      func(ctx context.Context, value string) error
+	LiveValidateDelay time.Duration
+	Timeout           time.Duration
+	TimeoutCancel     bool
+	Ephemeral         bool
+	LineMode          bool
+	Summary           func(value string) string
+	Settings          *SettingsOptions
+	Render            func(p *PathPrompt) string
 }
 
 // NewPathPrompt initializes and returns a new instance of PathPrompt.
@@ -44,13 +53,21 @@ type PathPromptParams struct {
 //
 // Parameters:
 //   - Context (context.Context): The context for the prompt (default: context.Background).
-//   - Input (*os.File): The input stream for the prompt (default: OSFileSystem).
-//   - Output (*os.File): The output stream for the prompt (default: OSFileSystem).
+//   - Input (io.Reader): The input stream for the prompt (default: os.Stdin).
+//   - Output (io.Writer): The output stream for the prompt (default: os.Stdout).
 //   - InitialValue (string): The initial value of the path input (default: current working directory).
 //   - OnlyShowDir (bool): Whether to only show directories (default: false).
 //   - Required (bool): Whether the path input is required (default: false).
 //   - FileSystem (FileSystem): The file system implementation to use (default: OSFileSystem).
 //   - Validate (func(value string) error): Custom validation function for the path (default: nil).
+//   - LiveValidate (func(ctx context.Context, value string) error): Validation function run as the user types, whose context is cancelled once the value changes (default: nil).
+//   - LiveValidateDelay (time.Duration): The time waited after the last keystroke before validating live (default: DefaultLiveValidateDelay).
+//   - Timeout (time.Duration): The time after which the current value is submitted, unless the user presses a key before (default: 0, no timeout).
+//   - TimeoutCancel (bool): Whether to cancel the prompt with ErrTimeout instead of submitting the current value on timeout (default: false).
+//   - Ephemeral (bool): Whether to erase the prompt once submitted, or replace it by its Summary (default: false).
+//   - LineMode (bool): Whether to read whole lines as answers, without raw mode or cursor movements, as done when the input is not a terminal (default: false).
+//   - Summary (func(value string) string): The one-line summary of the submitted value of an ephemeral prompt (default: nil, the prompt is erased).
+//   - Settings (*SettingsOptions): The key bindings and options of the prompt (default: the global Settings).
 //   - Render (func(p *PathPrompt) string): Custom render function for the prompt (default: nil).
 //
 // Returns:
@@ -66,13 +83,22 @@ func NewPathPrompt(params PathPromptParams) *PathPrompt {
 	var p PathPrompt
 	p = PathPrompt{
 		Prompt: *NewPrompt(PromptParams[string]{
-			Context:      params.Context,
-			Input:        params.Input,
-			Output:       params.Output,
-			InitialValue: params.InitialValue,
-			CursorIndex:  len(params.InitialValue),
-			Validate:     WrapValidate(params.Validate, &p.Required, "Path does not exist! Please enter a valid path."),
-			Render:       WrapRender[string](&p, params.Render),
+			Context:           params.Context,
+			Input:             params.Input,
+			Output:            params.Output,
+			InitialValue:      params.InitialValue,
+			CursorIndex:       utils.GraphemeCount(params.InitialValue),
+			Validate:          WrapValidate(params.Validate, &p.Required, "Path does not exist! Please enter a valid path."),
+			LiveValidate:      params.LiveValidate,
+			LiveValidateDelay: params.LiveValidateDelay,
+			ParseLine:         p.parseLine,
+			Timeout:           params.Timeout,
+			TimeoutCancel:     params.TimeoutCancel,
+			Ephemeral:         params.Ephemeral,
+			LineMode:          params.LineMode,
+			Summary:           params.Summary,
+			Settings:          params.Settings,
+			Render:            WrapRender[string](&p, params.Render),
 		}),
 		OnlyShowDir: params.OnlyShowDir,
 		HintIndex:   -1,
@@ -83,17 +109,31 @@ func NewPathPrompt(params PathPromptParams) *PathPrompt {
 	if cwd, err := p.FileSystem.Getwd(); err == nil && params.InitialValue == "" {
 		p.Prompt.Value = cwd
 		p.Value = cwd
-		p.CursorIndex = len(cwd)
+		p.CursorIndex = utils.GraphemeCount(cwd)
 	}
 	p.changeHint()
 
-	p.On(KeyEvent, func(args ...any) {
-		p.handleKeyPress(args[0].(*Key))
-	})
+	p.OnKey(p.handleKeyPress)
 
 	return &p
 }
 
+// parseLine parses a line answer into the path value.
+// An empty answer keeps the current value.
+//
+// Parameters:
+//   - line (string): The line answer to parse.
+//
+// Returns:
+//   - string: The parsed path.
+//   - error: Always nil.
+func (p *PathPrompt) parseLine(line string) (string, error) {
+	if line == "" {
+		return p.Value, nil
+	}
+	return line, nil
+}
+
 // mapHintOptions generates a list of hint options based on the current path value.
 // It filters entries in the current directory that match the end of the path value.
 //
@@ -165,17 +205,15 @@ func (p *PathPrompt) ValueWithCursor() string {
 		value string
 		hint  string
 	)
-	if p.CursorIndex >= len(p.Value) {
+	if clusters := utils.Graphemes(p.Value); p.CursorIndex >= len(clusters) {
 		value = p.Value
-		if p.Hint == "" {
+		if hintClusters := utils.Graphemes(p.Hint); len(hintClusters) == 0 {
 			hint = "█"
 		} else {
-			hint = picocolors.Inverse(string(p.Hint[0])) + picocolors.Dim(p.Hint[1:])
+			hint = picocolors.Inverse(hintClusters[0]) + picocolors.Dim(strings.Join(hintClusters[1:], ""))
 		}
 	} else {
-		s1 := p.Value[0:p.CursorIndex]
-		s2 := p.Value[p.CursorIndex:]
-		value = s1 + picocolors.Inverse(string(s2[0])) + s2[1:]
+		value = strings.Join(clusters[:p.CursorIndex], "") + picocolors.Inverse(clusters[p.CursorIndex]) + strings.Join(clusters[p.CursorIndex+1:], "")
 		hint = picocolors.Dim(p.Hint)
 	}
 	return value + hint
@@ -186,7 +224,7 @@ func (p *PathPrompt) ValueWithCursor() string {
 func (p *PathPrompt) completeValue() {
 	p.Value += p.Hint
 	p.Prompt.Value = p.Value
-	p.CursorIndex = len(p.Value)
+	p.CursorIndex = utils.GraphemeCount(p.Value)
 	p.Hint = ""
 	p.HintOptions = []string{}
 	p.changeHint()
@@ -216,9 +254,9 @@ func (p *PathPrompt) tabComplete() {
 //   - key (*Key): The key event to process.
 func (p *PathPrompt) handleKeyPress(key *Key) {
 	p.Value, p.CursorIndex = p.TrackKeyValue(key, p.Value, p.CursorIndex)
-	if key.Name == RightKey && p.CursorIndex >= len(p.Value) {
+	if key.Name == RightKey && p.CursorIndex >= utils.GraphemeCount(p.Value) {
 		p.completeValue()
-	} else if key.Name == TabKey {
+	} else if key.Binding() == TabKey {
 		p.tabComplete()
 	} else {
 		p.changeHint()
@@ -90,6 +90,18 @@ func TestPathComplete(t *testing.T) {
 	assert.Equal(t, expected, p.Value)
 }
 
+func TestPathUndoComplete(t *testing.T) {
+	p := newPathPrompt()
+	initialValue := p.Value
+
+	p.PressKey(&core.Key{Name: core.RightKey})
+	assert.NotEqual(t, initialValue, p.Value)
+
+	p.PressKey(&core.Key{Name: "z", Ctrl: true})
+	assert.Equal(t, initialValue, p.Value)
+	assert.Equal(t, len(initialValue), p.CursorIndex)
+}
+
 func TestPathValueWithHint(t *testing.T) {
 	p := newPathPrompt()
 
@@ -1,18 +1,18 @@
 package core
 
 import (
-	"bufio"
 	"context"
 	"flag"
+	"io"
 	"os"
 	"strings"
+	"sync"
 	"time"
 
+	"github.com/orochaa/go-clack/core/internals"
 	"github.com/orochaa/go-clack/core/utils"
 	"github.com/orochaa/go-clack/core/validator"
 	"github.com/orochaa/go-clack/third_party/sisteransi"
-
-	"golang.org/x/term"
 )
 
 type State int
@@ -33,12 +33,12 @@ const (
 )
 
 type Prompt[TValue any] struct {
-	context   context.Context
-	listeners map[Event][]EventListener
+	context context.Context
+	emitter *emitter
 
-	rl     *bufio.Reader
-	input  *os.File
-	output *os.File
+	rl     *inputReader
+	input  io.Reader
+	output io.Writer
 
 	State       State
 	Error       string
@@ -49,29 +49,87 @@ type Prompt[TValue any] struct {
 	ValidationDuration time.Duration
 	IsValidating       bool
 
+	LiveValidate      func(ctx context.Context, value TValue) error
+	LiveValidateDelay time.Duration
+	liveValue         TValue
+	liveTimer         *time.Timer
+	liveCancel        context.CancelFunc
+	validation        int
+	validationTimer   *time.Timer
+
+	Timeout          time.Duration
+	TimeoutCancel    bool
+	TimeoutRemaining time.Duration
+	timeoutTimer     *time.Timer
+	timedOut         bool
+
+	Ephemeral bool
+	Summary   func(value TValue) string
+
+	loop *eventLoop
+
 	Render func(p *Prompt[TValue]) string
 	Frame  string
+
+	LineMode  bool
+	ParseLine func(line string) (TValue, error)
+
+	Mouse    bool
+	Settings *SettingsOptions
+	// typesKeys reports whether keys are typed into the prompt (e.g. to filter its options),
+	// so the keymap does not apply, or nil if the keymap does not apply at all
+	typesKeys func() bool
+
+	frameRow int
+	// visibleOptions is the number of options rendered by LimitLines, without its ellipses
+	visibleOptions int
+	// optionLines are the frame lines of the options rendered by LimitLines, see markOptionLines
+	optionLines []optionLine
+
+	killRing []string
+	killing  bool
+	edits    editHistory
 }
 
 type PromptParams[TValue any] struct {
-	Context      context.Context
-	Input        *os.File
-	Output       *os.File
-	InitialValue TValue
-	CursorIndex  int
-	Validate     func(value TValue) error
-	Render       func(p *Prompt[TValue]) string
+	Context           context.Context
+	Input             io.Reader
+	Output            io.Writer
+	InitialValue      TValue
+	CursorIndex       int
+	Validate          func(value TValue) error
+	LiveValidate      func(ctx context.Context, value TValue) error
+	LiveValidateDelay time.Duration
+	Timeout           time.Duration
+	TimeoutCancel     bool
+	Ephemeral         bool
+	Summary           func(value TValue) string
+	LineMode          bool
+	ParseLine         func(line string) (TValue, error)
+	Mouse             bool
+	Settings          *SettingsOptions
+	Render            func(p *Prompt[TValue]) string
 }
 
 // NewPrompt initializes a new Prompt with the provided parameters.
 //
 // Parameters:
 //   - Context (context.Context): The context for the prompt (default: context.Background).
-//   - Input (*os.File): The input stream for the prompt (default: OSFileSystem).
-//   - Output (*os.File): The output stream for the prompt (default: OSFileSystem).
+//   - Input (io.Reader): The input stream for the prompt, see SharedInput to share it between prompts (default: os.Stdin).
+//   - Output (io.Writer): The output stream for the prompt (default: os.Stdout).
 //   - InitialValue (TValue): The initial value of the prompt (default: zero value of TValue).
 //   - CursorIndex (int): The initial cursor position in the input (default: 0).
 //   - Validate (func(value TValue) error): Custom validation function for the input (default: nil).
+//   - LiveValidate (func(ctx context.Context, value TValue) error): Validation function run as the value changes, whose context is cancelled once the value changes again. It also runs on submit (default: nil).
+//   - LiveValidateDelay (time.Duration): The time waited after the last change before validating live (default: DefaultLiveValidateDelay).
+//   - Timeout (time.Duration): The time after which the current value is submitted, unless the user presses a key before (default: 0, no timeout).
+//   - TimeoutCancel (bool): Whether to cancel the prompt with ErrTimeout instead of submitting the current value on timeout (default: false).
+//   - Ephemeral (bool): Whether to erase the frame once the prompt is submitted, or replace it by its Summary (default: false).
+//   - Summary (func(value TValue) string): The one-line summary of the submitted value of an ephemeral prompt (default: nil, the frame is erased).
+//   - LineMode (bool): Whether to run the prompt in line mode, see runLineMode, as done when the input is a RawTerminal which is not a terminal (default: false).
+//   - ParseLine (func(line string) (TValue, error)): Parses an answer read in line mode (default: the line itself for string prompts).
+//   - Mouse (bool): Whether to enable mouse reporting, see OptionAt (default: false).
+//   - Settings (*SettingsOptions): The key bindings and options of the prompt, see NewSettings (default: the global Settings).
 //   - Render (func(p *Prompt[TValue]) string): Custom render function for the prompt (default: nil).
 //
 // Returns:
@@ -83,172 +141,155 @@ func NewPrompt[TValue any](params PromptParams[TValue]) *Prompt[TValue] {
 	if params.Context == nil {
 		params.Context = context.Background()
 	}
-	if params.Input == nil {
-		params.Input = os.Stdin
-	}
 	if params.Output == nil {
 		params.Output = os.Stdout
 	}
+	if params.LiveValidateDelay == 0 {
+		params.LiveValidateDelay = DefaultLiveValidateDelay
+	}
+	rl, input := newInputReader(params.Input)
+	if file, ok := params.Output.(*os.File); ok {
+		params.Output = internals.OSTerminal{File: file}
+	}
 
 	return &Prompt[TValue]{
-		context:   params.Context,
-		listeners: make(map[Event][]EventListener),
+		context: params.Context,
+		emitter: newEmitter(),
 
-		input:  params.Input,
+		input:  input,
 		output: params.Output,
-		rl:     bufio.NewReader(params.Input),
+		rl:     rl,
 
 		State:       InitialState,
 		Value:       params.InitialValue,
 		CursorIndex: params.CursorIndex,
 
-		Validate: params.Validate,
-		Render:   params.Render,
+		Validate:          params.Validate,
+		LiveValidate:      params.LiveValidate,
+		LiveValidateDelay: params.LiveValidateDelay,
+		liveValue:         params.InitialValue,
+		Timeout:           params.Timeout,
+		TimeoutCancel:     params.TimeoutCancel,
+		Ephemeral:         params.Ephemeral,
+		Summary:           params.Summary,
+		loop:              newEventLoop(),
+
+		LineMode:  params.LineMode,
+		ParseLine: params.ParseLine,
+		Mouse:     params.Mouse,
+		Settings:  params.Settings,
+		Render:    params.Render,
 	}
 }
 
-type KeyName string
-
-type Key struct {
-	Name  KeyName
-	Char  string
-	Shift bool
-	Ctrl  bool
+// settings returns the settings of the prompt, or the global Settings if it has none.
+func (p *Prompt[TValue]) settings() *SettingsOptions {
+	return resolveSettings(p.Settings)
 }
 
-const (
-	UpKey        KeyName = "Up"
-	DownKey      KeyName = "Down"
-	LeftKey      KeyName = "Left"
-	RightKey     KeyName = "Right"
-	HomeKey      KeyName = "Home"
-	EndKey       KeyName = "End"
-	SpaceKey     KeyName = "Space"
-	EnterKey     KeyName = "Enter"
-	CancelKey    KeyName = "Cancel"
-	TabKey       KeyName = "Tab"
-	BackspaceKey KeyName = "Backspace"
-	EscapeKey    KeyName = "Escape"
-)
-
-// ParseKey parses a rune into a Key.
-func (p *Prompt[TValue]) ParseKey(r rune) *Key {
-	// TODO: parse Backtab(shift+tab) and other variations of shift and ctrl
-	switch r {
-	case '\r', '\n':
-		return &Key{Name: EnterKey}
-	case ' ':
-		return &Key{Name: SpaceKey}
-	case '\b', 127:
-		return &Key{Name: BackspaceKey}
-	case '\t':
-		return &Key{Name: TabKey}
-	case 3:
-		return &Key{Name: CancelKey}
-	case 27:
-		readerReady := make(chan bool, 1)
-		go func() {
-			_, err := p.rl.Peek(2)
-			readerReady <- err == nil
-		}()
-
-		select {
-		case ready := <-readerReady:
-			if ready {
-				next, err := p.rl.Peek(2)
-				if err == nil && len(next) == 2 && next[0] == '[' {
-					p.rl.ReadByte() // Consume '['
-					thirdByte, _ := p.rl.ReadByte()
-
-					switch thirdByte {
-					case 'A':
-						return &Key{Name: UpKey}
-					case 'B':
-						return &Key{Name: DownKey}
-					case 'C':
-						return &Key{Name: RightKey}
-					case 'D':
-						return &Key{Name: LeftKey}
-					case 'H':
-						return &Key{Name: HomeKey}
-					case 'F':
-						return &Key{Name: EndKey}
-					}
-				}
-				return &Key{}
-			} else {
-				return &Key{Name: EscapeKey}
-			}
-
-		case <-time.After(50 * time.Millisecond):
-			return &Key{Name: EscapeKey}
+// lookupAction returns the action bound to a key in the settings of the prompt.
+// The bindings of the keymap take precedence, except while keys are typed into the prompt, see typesKeys.
+func (p *Prompt[TValue]) lookupAction(key *Key) (action Action, exists bool) {
+	settings := p.settings()
+	if settings.Keymap != nil && p.typesKeys != nil && !p.typesKeys() {
+		if action, exists = settings.Keymap[key.Binding()]; exists {
+			return action, true
 		}
 	}
+	return settings.lookupAction(key)
+}
 
-	char := string(r)
-	return &Key{Char: char, Name: KeyName(char)}
+// NewActionHandler creates an action handler like the global NewActionHandler,
+// but looking up the actions in the settings of the prompt, including its keymap.
+func (p *Prompt[TValue]) NewActionHandler(listeners map[Action]func(), defaultListener func(key *Key)) (actionHandler func(key *Key)) {
+	return newActionHandler(p.lookupAction, listeners, defaultListener)
 }
 
 // PressKey handles key press events and updates the state of the prompt.
+// While the prompt runs, the key is handled by its event loop, and PressKey returns once it is handled,
+// including the validation of a submitted value.
 func (p *Prompt[TValue]) PressKey(key *Key) {
+	p.dispatch(func(done func()) { p.pressKey(key, done) })
+}
+
+// pressKey handles a key press on the event loop.
+// Keys are dropped while a submitted value is validated, except the CancelAction, which cancels the prompt.
+// Otherwise, the key stops the countdown of the Timeout.
+//
+// Parameters:
+//   - key (*Key): The pressed key.
+//   - done (func()): Called once the key is fully handled.
+func (p *Prompt[TValue]) pressKey(key *Key, done func()) {
+	if p.IsValidating {
+		if action, actionExists := p.lookupAction(key); actionExists && action == CancelAction {
+			p.cancel()
+		}
+		done()
+		return
+	}
+
+	p.stopTimeout()
 	if p.State == InitialState || p.State == ErrorState {
 		p.State = ActiveState
 	}
 
+	if key.Name == PasteKey {
+		p.Emit(PasteEvent, key.Char)
+	}
 	p.Emit(KeyEvent, key)
 
-	if action, actionExists := Settings.Aliases[key.Name]; actionExists {
-		if action == SubmitAction {
-			if err := p.validate(); err != nil {
+	// Listeners may change the key, e.g. to prevent a submission
+	action, actionExists := p.lookupAction(key)
+	if actionExists && action == SubmitAction {
+		p.validate(func(err error) {
+			if err != nil {
 				p.State = ErrorState
 				p.Error = err.Error()
+				p.Emit(ErrorEvent, err)
 			} else {
 				p.State = SubmitState
 			}
-		} else if action == CancelAction {
-			p.State = CancelState
-		}
+			p.update()
+			done()
+		})
+		return
+	}
+	if actionExists && action == CancelAction {
+		p.State = CancelState
+	}
+
+	p.update()
+	done()
+}
+
+// cancel cancels the prompt, abandoning the validation in progress.
+func (p *Prompt[TValue]) cancel() {
+	if p.IsValidating {
+		p.stopValidation()
 	}
+	p.State = CancelState
+	p.update()
+}
 
+// update renders the current state, and emits the final events once the prompt is submitted or cancelled.
+func (p *Prompt[TValue]) update() {
 	if p.State == SubmitState || p.State == CancelState {
+		p.stopLiveValidation()
+		p.stopTimeout()
 		p.Emit(FinalizeEvent)
+	} else {
+		p.scheduleLiveValidation()
 	}
 
 	p.render()
 
 	if p.State == SubmitState {
-		p.Emit(SubmitEvent)
+		p.Emit(SubmitEvent, p.Value)
 	} else if p.State == CancelState {
 		p.Emit(CancelEvent)
 	}
 }
 
-// validate performs validation on the current value of the prompt.
-func (p *Prompt[TValue]) validate() error {
-	if p.Validate == nil {
-		return nil
-	}
-
-	p.State = ValidateState
-	p.IsValidating = true
-	p.Emit(ValidateEvent)
-
-	go func() {
-		validationStart := time.Now()
-		time.Sleep(400 * time.Millisecond)
-		for p.IsValidating {
-			p.ValidationDuration = time.Since(validationStart)
-			p.render()
-			time.Sleep(125 * time.Millisecond)
-		}
-	}()
-
-	err := p.Validate(p.Value)
-	p.IsValidating = false
-
-	return err
-}
-
 // DiffLines calculates the difference between an old and a new frame.
 func (p *Prompt[TValue]) DiffLines(oldFrame, newFrame string) []int {
 	var diff []int
@@ -269,18 +310,31 @@ func (p *Prompt[TValue]) DiffLines(oldFrame, newFrame string) []int {
 }
 
 // Size retrieves the width and height of the terminal output.
+// It returns ErrNoTerminal if the output does not implement SizedTerminal.
 func (p *Prompt[TValue]) Size() (width int, height int, err error) {
-	return term.GetSize(int(p.output.Fd()))
+	if output, ok := p.output.(SizedTerminal); ok {
+		return output.Size()
+	}
+	return 0, 0, ErrNoTerminal
+}
+
+// write writes a string to the output.
+func (p *Prompt[TValue]) write(str string) {
+	io.WriteString(p.output, str)
 }
 
 // render renders a new frame to the output.
 func (p *Prompt[TValue]) render() {
-	frame := p.Render(p)
+	frame := p.markOptionLines(p.Render(p))
+	if p.State == SubmitState && p.Ephemeral {
+		frame = p.summary()
+	}
 
 	if p.State == InitialState {
-		p.output.WriteString(sisteransi.HideCursor())
-		p.output.WriteString(frame)
+		p.write(sisteransi.HideCursor())
+		p.write(frame)
 		p.Frame = frame
+		p.trackFrameRow()
 		return
 	}
 
@@ -290,75 +344,137 @@ func (p *Prompt[TValue]) render() {
 
 	diff := p.DiffLines(frame, p.Frame)
 	diffLineIndex := diff[0]
-	prevFrameLines := utils.SplitLines((p.Frame))
-
-	// Move to first diff line
-	p.output.WriteString(sisteransi.MoveCursor(-(len(prevFrameLines) - 1), -999))
-	p.output.WriteString(sisteransi.MoveCursor(diffLineIndex, 0))
-	p.output.WriteString(sisteransi.EraseDown())
+	width := p.width()
 	lines := utils.SplitLines(frame)
+
+	// Move to first diff line, counting the rows of wrapped lines
+	p.write(sisteransi.MoveCursor(-(utils.CountRows(p.Frame, width) - 1), -999))
+	if diffLineIndex > 0 {
+		p.write(sisteransi.MoveCursor(utils.CountRows(strings.Join(lines[:diffLineIndex], "\r\n"), width), 0))
+	}
+	p.write(sisteransi.EraseDown())
 	newLines := lines[diffLineIndex:]
-	p.output.WriteString(strings.Join(newLines, "\r\n"))
+	p.write(strings.Join(newLines, "\r\n"))
 	p.Frame = frame
+	p.trackFrameRow()
 }
 
-// Run runs the prompt and processes input.
-func (p *Prompt[TValue]) Run() (TValue, error) {
-	var oldState *term.State
-	if flag.Lookup("test.v") == nil {
-		var err error
-		oldState, err = term.MakeRaw(int(p.input.Fd()))
-		if err != nil {
-			return p.Value, err
-		}
-		defer term.Restore(int(p.input.Fd()), oldState)
+// summary returns the frame replacing the one of an ephemeral prompt once submitted,
+// which is its Summary, or an empty frame erasing the prompt.
+func (p *Prompt[TValue]) summary() string {
+	if p.Summary == nil {
+		return ""
 	}
+	return p.Summary(p.Value)
+}
 
-	done := make(chan struct{})
-	closeCb := func(args ...any) {
-		p.output.WriteString(sisteransi.ShowCursor())
-		p.output.WriteString("\r\n")
-		close(done)
+// redraw erases the current frame and renders it again from scratch.
+// It is used once the terminal is resized, as the terminal may have wrapped the previous frame differently.
+func (p *Prompt[TValue]) redraw() {
+	p.write(sisteransi.MoveCursor(-(utils.CountRows(p.Frame, p.width()) - 1), -999))
+	p.write(sisteransi.EraseDown())
+	if p.Mouse {
+		p.requestFrameRow()
 	}
-	p.Once(SubmitEvent, closeCb)
-	p.Once(CancelEvent, closeCb)
 
-	p.render()
+	frame := p.markOptionLines(p.Render(p))
+	p.write(frame)
+	p.Frame = frame
+	p.trackFrameRow()
+}
 
-	go func() {
-		select {
-		case <-done:
-			return
-		case <-p.context.Done():
-			// Restore terminal immediately when context is cancelled
-			if oldState != nil {
-				term.Restore(int(p.input.Fd()), oldState)
-			}
-			p.PressKey(&Key{Name: CancelKey})
+// width returns the width of the terminal output, or 0 if it is unknown.
+func (p *Prompt[TValue]) width() int {
+	width, _, err := p.Size()
+	if err != nil {
+		return 0
+	}
+	return width
+}
+
+// enableInputModes enables the terminal input modes used while the prompt runs.
+// Bracketed paste is always enabled, so pasted text is read as a single PasteKey,
+// the kitty keyboard protocol is queried if Settings.KittyKeyboard is set, and mouse reporting is enabled if Mouse is set.
+// Terminals without support for a mode ignore the request and keep sending legacy sequences.
+// The kitty keyboard flags are only pushed once the terminal answers the query, see pushKittyKeyboard.
+//
+// Parameters:
+//   - restore (func() error): The function restoring the terminal from raw mode.
+//
+// Returns:
+//   - func() error: A function that disables the input modes and then restores the terminal, at most once.
+func (p *Prompt[TValue]) enableInputModes(restore func() error) func() error {
+	kittyKeyboard, mouse := p.settings().KittyKeyboard, p.Mouse
+	p.write(bracketedPasteEnable)
+	if kittyKeyboard {
+		p.loop.kittyReportPending.Store(true)
+		p.write(kittyKeyboardQuery)
+	}
+	if mouse {
+		p.write(mouseEnable)
+	}
+	return sync.OnceValue(func() error {
+		if mouse {
+			p.write(mouseDisable)
 		}
-	}()
-
-outer:
-	for {
-		select {
-		case <-done:
-			break outer
-		default:
-			r, size, err := p.rl.ReadRune()
-			if err != nil || size == 0 || p.IsValidating {
-				continue
-			}
-			// Check if cancelled while we were blocked on ReadRune
-			select {
-			case <-done:
-				break outer
-			default:
+		p.loop.kittyReportPending.Store(false)
+		if p.loop.kittyKeyboardPushed.Swap(false) {
+			p.write(kittyKeyboardDisable)
+		}
+		p.write(bracketedPasteDisable)
+		return restore()
+	})
+}
+
+// pushKittyKeyboard pushes the kitty keyboard flags once the terminal has answered the query of enableInputModes.
+// Answers that were not requested, e.g. once the terminal is restored, are ignored.
+func (p *Prompt[TValue]) pushKittyKeyboard() {
+	if p.loop.kittyReportPending.CompareAndSwap(true, false) {
+		p.loop.kittyKeyboardPushed.Store(true)
+		p.write(kittyKeyboardEnable)
+	}
+}
+
+// Run runs the prompt and processes input.
+// The state of the prompt is owned by its event loop until it is submitted or cancelled (see runLoop).
+// If LineMode is set or the input is not a terminal, the prompt runs in line mode (see runLineMode).
+func (p *Prompt[TValue]) Run() (TValue, error) {
+	var restore func() error
+	if input, ok := p.input.(RawTerminal); ok && !p.LineMode {
+		if !input.IsTerminal() {
+			p.LineMode = true
+		} else if flag.Lookup("test.v") == nil {
+			var err error
+			restore, err = input.MakeRaw()
+			if err != nil {
+				return p.Value, err
 			}
-			key := p.ParseKey(r)
-			p.PressKey(key)
+			restore = p.enableInputModes(restore)
+			defer restore()
+		}
+	}
+
+	if p.LineMode {
+		return p.runLineMode()
+	}
+
+	closeCb := func(args ...any) {
+		p.write(sisteransi.ShowCursor())
+		// An erased ephemeral prompt leaves no line behind
+		if p.Frame != "" {
+			p.write("\r\n")
 		}
 	}
+	unsubscribeSubmit := p.Once(SubmitEvent, closeCb)
+	defer unsubscribeSubmit()
+	unsubscribeCancel := p.Once(CancelEvent, closeCb)
+	defer unsubscribeCancel()
 
+	p.runLoop(restore)
+
+	if p.timedOut {
+		return p.Value, ErrTimeout
+	}
 	if p.State == CancelState {
 		return p.Value, ErrCancelPrompt
 	}
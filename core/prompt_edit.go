@@ -0,0 +1,129 @@
+package core
+
+import (
+	"strings"
+	"unicode"
+)
+
+// maxKillRingSize is the number of killed texts kept by a prompt.
+const maxKillRingSize = 10
+
+// editValue applies a readline editing action to a value split in grapheme clusters.
+// Killed texts are pushed to the kill ring of the prompt, and consecutive kills are merged into a single entry,
+// so they can be yanked back at once.
+//
+// Parameters:
+//   - action (Action): The editing action to apply.
+//   - clusters ([]string): The grapheme clusters of the value.
+//   - cursorIndex (int): The cursor position, in grapheme clusters.
+//
+// Returns:
+//   - newValue (string): The edited value.
+//   - newCursorIndex (int): The new cursor position.
+//   - ok (bool): Whether the action is an editing action.
+func (p *Prompt[TValue]) editValue(action Action, clusters []string, cursorIndex int) (newValue string, newCursorIndex int, ok bool) {
+	value := strings.Join(clusters, "")
+	killing := p.killing
+	p.killing = false
+
+	switch action {
+	case LineStartAction:
+		return value, 0, true
+	case LineEndAction:
+		return value, len(clusters), true
+	case WordLeftAction:
+		return value, wordStart(clusters, cursorIndex, isWordCluster), true
+	case WordRightAction:
+		return value, wordEnd(clusters, cursorIndex, isWordCluster), true
+	case DeleteForwardAction:
+		if cursorIndex == len(clusters) {
+			return value, cursorIndex, true
+		}
+		return strings.Join(clusters[:cursorIndex], "") + strings.Join(clusters[cursorIndex+1:], ""), cursorIndex, true
+	case DeleteWordAction:
+		start := wordStart(clusters, cursorIndex, func(cluster string) bool { return strings.TrimSpace(cluster) != "" })
+		newValue, newCursorIndex = p.kill(clusters, start, cursorIndex, killing, true)
+		return newValue, newCursorIndex, true
+	case KillLineStartAction:
+		newValue, newCursorIndex = p.kill(clusters, 0, cursorIndex, killing, true)
+		return newValue, newCursorIndex, true
+	case KillLineEndAction:
+		newValue, newCursorIndex = p.kill(clusters, cursorIndex, len(clusters), killing, false)
+		return newValue, newCursorIndex, true
+	case YankAction:
+		if len(p.killRing) == 0 {
+			return value, cursorIndex, true
+		}
+		newValue, newCursorIndex = insertText(clusters, cursorIndex, p.killRing[len(p.killRing)-1])
+		return newValue, newCursorIndex, true
+	}
+
+	return value, cursorIndex, false
+}
+
+// kill removes the clusters between start and end from the value and pushes them to the kill ring.
+//
+// Parameters:
+//   - clusters ([]string): The grapheme clusters of the value.
+//   - start (int): The index of the first killed cluster.
+//   - end (int): The index after the last killed cluster.
+//   - merge (bool): Whether to merge the killed text into the last entry of the kill ring.
+//   - backward (bool): Whether the text is killed backward, so it is prepended to the merged entry.
+//
+// Returns:
+//   - newValue (string): The value without the killed text.
+//   - newCursorIndex (int): The new cursor position, at the start of the killed text.
+func (p *Prompt[TValue]) kill(clusters []string, start, end int, merge bool, backward bool) (newValue string, newCursorIndex int) {
+	killed := strings.Join(clusters[start:end], "")
+	newValue = strings.Join(clusters[:start], "") + strings.Join(clusters[end:], "")
+	if killed == "" {
+		return newValue, start
+	}
+
+	p.killing = true
+	if merge && len(p.killRing) > 0 {
+		last := len(p.killRing) - 1
+		if backward {
+			p.killRing[last] = killed + p.killRing[last]
+		} else {
+			p.killRing[last] += killed
+		}
+		return newValue, start
+	}
+
+	p.killRing = append(p.killRing, killed)
+	if len(p.killRing) > maxKillRingSize {
+		p.killRing = p.killRing[1:]
+	}
+	return newValue, start
+}
+
+// isWordCluster reports whether a grapheme cluster is part of a word, made of letters and digits.
+func isWordCluster(cluster string) bool {
+	r := []rune(cluster)[0]
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// wordStart returns the start of the word before the cursor, skipping the separators right before it.
+func wordStart(clusters []string, cursorIndex int, isWord func(cluster string) bool) int {
+	i := cursorIndex
+	for i > 0 && !isWord(clusters[i-1]) {
+		i--
+	}
+	for i > 0 && isWord(clusters[i-1]) {
+		i--
+	}
+	return i
+}
+
+// wordEnd returns the end of the word after the cursor, skipping the separators right after it.
+func wordEnd(clusters []string, cursorIndex int, isWord func(cluster string) bool) int {
+	i := cursorIndex
+	for i < len(clusters) && !isWord(clusters[i]) {
+		i++
+	}
+	for i < len(clusters) && isWord(clusters[i]) {
+		i++
+	}
+	return i
+}
@@ -1,16 +1,20 @@
 package core
 
-import "fmt"
+import (
+	"reflect"
+	"sync"
+)
 
 // Event represents the type of events that can occur.
+// Each event has a typed payload, which is received by the typed subscription methods (e.g. OnKey for KeyEvent).
 type Event int
 
 const (
-	// KeyEvent is emitted after each user's input
+	// KeyEvent is emitted after each user's input, with the related *Key
 	KeyEvent Event = iota
 	// ValidateEvent is emitted when the input is being validated
 	ValidateEvent
-	// ErrorEvent is emitted if an error occurs during the validation process
+	// ErrorEvent is emitted if an error occurs during the validation process, with the related error
 	ErrorEvent
 	// FinalizeEvent is emitted on user's submit or cancel, and before rendering the related state
 	FinalizeEvent
@@ -18,39 +22,161 @@ const (
 	CancelEvent
 	// SubmitEvent is emitted after the user submits the input, and after rendering the submit state
 	SubmitEvent
+	// PasteEvent is emitted when the user pastes a text, before the KeyEvent of the related PasteKey
+	PasteEvent
+	// MouseEvent is emitted after each mouse event, with the related *Mouse
+	MouseEvent
 )
 
 type EventListener func(args ...any)
 
+// subscription is a listener registered for an event.
+type subscription struct {
+	id       int
+	listener EventListener
+}
+
+// emitter holds the listeners of a prompt.
+// Listeners may be registered, removed and emitted from any goroutine.
+type emitter struct {
+	mu            sync.Mutex
+	nextID        int
+	subscriptions map[Event][]subscription
+}
+
+func newEmitter() *emitter {
+	return &emitter{subscriptions: make(map[Event][]subscription)}
+}
+
 // On registers a listener for the specified event.
-func (p *Prompt[TValue]) On(event Event, listener EventListener) {
-	p.listeners[event] = append(p.listeners[event], listener)
+//
+// Returns:
+//   - unsubscribe (func()): Removes the listener. Calling it more than once has no effect.
+func (p *Prompt[TValue]) On(event Event, listener EventListener) (unsubscribe func()) {
+	if p.emitter == nil {
+		p.emitter = newEmitter()
+	}
+	e := p.emitter
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.nextID++
+	id := e.nextID
+	e.subscriptions[event] = append(e.subscriptions[event], subscription{id, listener})
+
+	return func() {
+		e.mu.Lock()
+		defer e.mu.Unlock()
+		subscriptions := e.subscriptions[event]
+		for i, s := range subscriptions {
+			if s.id == id {
+				e.subscriptions[event] = append(subscriptions[:i:i], subscriptions[i+1:]...)
+				break
+			}
+		}
+	}
 }
 
 // Once registers a one-time listener for the specified event.
-func (p *Prompt[TValue]) Once(event Event, listener EventListener) {
-	var onceListener EventListener
-	onceListener = func(args ...any) {
-		listener(args)
-		p.Off(event, onceListener)
-	}
-	p.On(event, onceListener)
+// The listener is removed before it is called, so it is called once even if the event is emitted again meanwhile.
+//
+// Returns:
+//   - unsubscribe (func()): Removes the listener if it has not been called yet.
+func (p *Prompt[TValue]) Once(event Event, listener EventListener) (unsubscribe func()) {
+	var once sync.Once
+	unsubscribe = p.On(event, func(args ...any) {
+		called := false
+		once.Do(func() { called = true })
+		if called {
+			unsubscribe()
+			listener(args...)
+		}
+	})
+	return unsubscribe
 }
 
 // Off removes a listener for the specified event.
+//
+// Deprecated: Listeners are compared by their code, so closures created by the same function can not be told apart.
+// Use the unsubscribe function returned by On instead.
 func (p *Prompt[TValue]) Off(event Event, listener EventListener) {
-	listeners := p.listeners[event]
-	for i, l := range listeners {
-		if fmt.Sprintf("%p", l) == fmt.Sprintf("%p", listener) {
-			p.listeners[event] = append(listeners[:i], listeners[i+1:]...)
+	if p.emitter == nil {
+		return
+	}
+	e := p.emitter
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	pointer := reflect.ValueOf(listener).Pointer()
+	subscriptions := e.subscriptions[event]
+	for i, s := range subscriptions {
+		if reflect.ValueOf(s.listener).Pointer() == pointer {
+			e.subscriptions[event] = append(subscriptions[:i:i], subscriptions[i+1:]...)
 			break
 		}
 	}
 }
 
 // Emit triggers the specified event with the given arguments.
+// The listeners are called in their registration order, and may register or remove listeners themselves.
 func (p *Prompt[TValue]) Emit(event Event, args ...any) {
-	for _, listener := range p.listeners[event] {
-		listener(args...)
+	if p.emitter == nil {
+		return
+	}
+	e := p.emitter
+	e.mu.Lock()
+	subscriptions := e.subscriptions[event]
+	e.mu.Unlock()
+
+	for _, s := range subscriptions {
+		s.listener(args...)
+	}
+}
+
+// eventArg returns the payload of an event, or the zero value of T if the event was emitted without it.
+func eventArg[T any](args []any) T {
+	var value T
+	if len(args) > 0 {
+		value, _ = args[0].(T)
 	}
+	return value
+}
+
+// OnKey registers a listener for KeyEvent, which receives the pressed key.
+func (p *Prompt[TValue]) OnKey(listener func(key *Key)) (unsubscribe func()) {
+	return p.On(KeyEvent, func(args ...any) { listener(eventArg[*Key](args)) })
+}
+
+// OnMouse registers a listener for MouseEvent, which receives the mouse event.
+func (p *Prompt[TValue]) OnMouse(listener func(mouse *Mouse)) (unsubscribe func()) {
+	return p.On(MouseEvent, func(args ...any) { listener(eventArg[*Mouse](args)) })
+}
+
+// OnPaste registers a listener for PasteEvent, which receives the pasted text.
+func (p *Prompt[TValue]) OnPaste(listener func(text string)) (unsubscribe func()) {
+	return p.On(PasteEvent, func(args ...any) { listener(eventArg[string](args)) })
+}
+
+// OnValidate registers a listener for ValidateEvent, which receives the value being validated.
+func (p *Prompt[TValue]) OnValidate(listener func(value TValue)) (unsubscribe func()) {
+	return p.On(ValidateEvent, func(args ...any) { listener(eventArg[TValue](args)) })
+}
+
+// OnError registers a listener for ErrorEvent, which receives the validation error.
+func (p *Prompt[TValue]) OnError(listener func(err error)) (unsubscribe func()) {
+	return p.On(ErrorEvent, func(args ...any) { listener(eventArg[error](args)) })
+}
+
+// OnFinalize registers a listener for FinalizeEvent.
+func (p *Prompt[TValue]) OnFinalize(listener func()) (unsubscribe func()) {
+	return p.On(FinalizeEvent, func(args ...any) { listener() })
+}
+
+// OnSubmit registers a listener for SubmitEvent, which receives the submitted value.
+func (p *Prompt[TValue]) OnSubmit(listener func(value TValue)) (unsubscribe func()) {
+	return p.On(SubmitEvent, func(args ...any) { listener(eventArg[TValue](args)) })
+}
+
+// OnCancel registers a listener for CancelEvent.
+func (p *Prompt[TValue]) OnCancel(listener func()) (unsubscribe func()) {
+	return p.On(CancelEvent, func(args ...any) { listener() })
 }
@@ -3,45 +3,105 @@ package core
 import (
 	"math"
 	"strings"
+	"unicode"
 
 	"github.com/orochaa/go-clack/core/utils"
 	"github.com/orochaa/go-clack/third_party/picocolors"
 )
 
 // TrackKeyValue updates the string value and cursor position based on key presses.
+// The cursor position is counted in grapheme clusters, so multi-byte characters and emojis are edited as a whole.
+// Keys bound to readline editing actions (e.g. Ctrl+W or Alt+B) edit the value, see editValue.
+// Edits are recorded in an undo stack, so they can be reverted with UndoAction and restored with RedoAction.
 func (p *Prompt[TValue]) TrackKeyValue(key *Key, value string, cursorIndex int) (newValue string, newCursorIndex int) {
-	switch key.Name {
-	case BackspaceKey:
-		if cursorIndex == 0 || len(value) == 0 {
-			return value, cursorIndex
+	p.syncEdit(value, cursorIndex)
+
+	if action, actionExists := p.lookupAction(key); actionExists && key.Char == "" {
+		switch action {
+		case UndoAction:
+			return p.undoEdit(value, cursorIndex)
+		case RedoAction:
+			return p.redoEdit(value, cursorIndex)
 		}
+	}
+
+	newValue, newCursorIndex = p.trackKeyValue(key, value, cursorIndex)
 
-		cursorIndex--
-		if cursorIndex == len(value)-1 {
-			return value[0:cursorIndex], cursorIndex
+	var typed string
+	if key.Name == SpaceKey {
+		typed = " "
+	} else if key.Name != PasteKey {
+		typed = key.Char
+	}
+	p.recordEdit(editState{value, cursorIndex}, editState{newValue, newCursorIndex}, typed)
+
+	return newValue, newCursorIndex
+}
+
+// trackKeyValue applies a key press to the string value, see TrackKeyValue.
+func (p *Prompt[TValue]) trackKeyValue(key *Key, value string, cursorIndex int) (newValue string, newCursorIndex int) {
+	clusters := utils.Graphemes(value)
+	cursorIndex = max(min(cursorIndex, len(clusters)), 0)
+
+	if action, actionExists := p.lookupAction(key); actionExists && key.Char == "" {
+		if newValue, newCursorIndex, ok := p.editValue(action, clusters, cursorIndex); ok {
+			return newValue, newCursorIndex
 		}
+	}
+	p.killing = false
 
-		return value[0:cursorIndex] + value[cursorIndex+1:], cursorIndex
+	switch key.Name {
+	case BackspaceKey:
+		if cursorIndex == 0 {
+			return value, cursorIndex
+		}
+		return strings.Join(clusters[:cursorIndex-1], "") + strings.Join(clusters[cursorIndex:], ""), cursorIndex - 1
 	case HomeKey:
 		return value, 0
 	case EndKey:
-		return value, len(value)
+		return value, len(clusters)
 	case LeftKey:
 		return value, max(cursorIndex-1, 0)
 	case RightKey:
-		return value, min(cursorIndex+1, len(value))
+		return value, min(cursorIndex+1, len(clusters))
 	case SpaceKey:
-		return value[0:cursorIndex] + " " + value[cursorIndex:], cursorIndex + 1
+		return insertText(clusters, cursorIndex, " ")
+	case PasteKey:
+		return insertText(clusters, cursorIndex, sanitizePaste(key.Char))
 	}
 
-	if len(key.Char) == 1 {
-		return value[0:cursorIndex] + key.Char + value[cursorIndex:], cursorIndex + 1
+	if key.Char != "" && !strings.ContainsFunc(key.Char, unicode.IsControl) {
+		return insertText(clusters, cursorIndex, key.Char)
 	}
 
 	return value, cursorIndex
 }
 
+// insertText inserts a text into a value split in grapheme clusters, at the cursor position.
+// The new cursor position is placed after the inserted text, which may have merged into the previous cluster,
+// e.g. a combining accent typed after its base letter.
+func insertText(clusters []string, cursorIndex int, text string) (newValue string, newCursorIndex int) {
+	before := strings.Join(clusters[:cursorIndex], "") + text
+	return before + strings.Join(clusters[cursorIndex:], ""), utils.GraphemeCount(before)
+}
+
+// sanitizePaste prepares a pasted text to be inserted into a single line value.
+// Line breaks and tabs are replaced by spaces, and other control characters are removed.
+func sanitizePaste(text string) string {
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r == '\n' || r == '\r' || r == '\t':
+			return ' '
+		case unicode.IsControl(r):
+			return -1
+		}
+		return r
+	}, text)
+}
+
 // LimitLines limits the number of lines to fit within the terminal size.
+// If Mouse is set, the last line of each visible line is marked, so mouse events can be mapped back to them (see OptionAt).
 func (p *Prompt[TValue]) LimitLines(lines []string, usedLines int) string {
 	_, maxRows, err := p.Size()
 	if err != nil {
@@ -57,6 +117,7 @@ func (p *Prompt[TValue]) LimitLines(lines []string, usedLines int) string {
 	}
 
 	result := make([]string, 0, maxItems)
+	p.visibleOptions = 0
 	shouldRenderTopEllipsis := maxItems < len(lines) && slidingWindowLocation > 0
 	shouldRenderBottomEllipsis := maxItems < len(lines) && slidingWindowLocation+maxItems < len(lines)
 
@@ -67,7 +128,12 @@ func (p *Prompt[TValue]) LimitLines(lines []string, usedLines int) string {
 			result = append(result, picocolors.Dim("..."))
 			continue
 		}
+		if p.Mouse {
+			lastLine := strings.LastIndexAny(line, "\r\n") + 1
+			line = line[:lastLine] + optionMarker(slidingWindowLocation+i) + line[lastLine:]
+		}
 		result = append(result, line)
+		p.visibleOptions++
 	}
 
 	return strings.Join(result, "\r\n")
@@ -286,20 +352,19 @@ func (p *Prompt[TValue]) FormatLines(lines []string, options FormatLinesOptions)
 			} else if utils.StrLength(currentLine+word)+emptySlots+1 <= maxWith {
 				currentLine += " " + word
 			} else if utils.StrLength(word)+emptySlots >= maxWith {
-				var splitIndex int
+				var head, chunk string
 				if utils.StrLength(currentLine) == 0 {
-					splitIndex = maxWith - emptySlots
-					formatAndAddLine(word[0:splitIndex])
+					head, chunk = utils.SplitAtWidth(word, maxWith-emptySlots)
+					formatAndAddLine(head)
 				} else {
-					splitIndex = maxWith - utils.StrLength(currentLine) - emptySlots - 1
-					formatAndAddLine(currentLine + " " + word[0:splitIndex])
+					head, chunk = utils.SplitAtWidth(word, maxWith-utils.StrLength(currentLine)-emptySlots-1)
+					formatAndAddLine(currentLine + " " + head)
 				}
 
 				chunkLength := maxWith - emptySlots
-				chunk := word[splitIndex:]
 				for utils.StrLength(chunk) > chunkLength {
-					formatAndAddLine(chunk[0:chunkLength])
-					chunk = chunk[chunkLength:]
+					head, chunk = utils.SplitAtWidth(chunk, chunkLength)
+					formatAndAddLine(head)
 				}
 
 				currentLine = chunk
@@ -0,0 +1,244 @@
+package core
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/orochaa/go-clack/core/internals"
+)
+
+// errInputStopped is returned by the reads of an inputReader abandoned by their prompt, see inputReader.fill.
+var errInputStopped = errors.New("input read stopped")
+
+// inputReader reads the input of a prompt, or of all the prompts sharing it through a SharedInput,
+// so the bytes read ahead for a prompt (e.g. typed ahead, pasted or piped) are left to the next one.
+// The source is only read while a prompt waits for input, one chunk at a time, in a goroutine,
+// so a read outliving the prompt that started it hands its bytes to the next prompt instead of losing them.
+type inputReader struct {
+	source io.Reader
+
+	mu  sync.Mutex
+	buf []byte
+	err error
+	// reading is closed once the read of the source in progress returns, or nil if none is in progress
+	reading chan struct{}
+	// recorded holds the bytes consumed while recording, see record
+	recorded  []byte
+	recording bool
+}
+
+// SharedInput is an input shared by consecutive prompts, so the bytes read ahead by a prompt
+// (e.g. typed ahead, pasted or piped) are left to the next one instead of being lost.
+// Prompts without an Input share the input of os.Stdin, while prompts given any other Input read it on their own,
+// unless the same SharedInput is passed to each of them.
+type SharedInput struct {
+	reader *inputReader
+}
+
+// NewSharedInput creates an input to be shared by the prompts reading from the source.
+// The bytes read ahead are held by the SharedInput, so it should be dropped along with the source, e.g. once a session ends.
+//
+// Parameters:
+//   - source (io.Reader): The input stream read by the prompts.
+//
+// Returns:
+//   - *SharedInput: A new instance of SharedInput.
+func NewSharedInput(source io.Reader) *SharedInput {
+	if file, ok := source.(*os.File); ok {
+		source = internals.OSTerminal{File: file}
+	}
+	return &SharedInput{reader: &inputReader{source: source}}
+}
+
+// Read reads the bytes read ahead by the prompts first, and then the source.
+func (s *SharedInput) Read(b []byte) (int, error) {
+	if len(b) == 0 {
+		return 0, nil
+	}
+	err := s.reader.fill(nil, 0, func(buf []byte) bool { return len(buf) > 0 })
+	if err != nil && !s.reader.buffered() {
+		return 0, err
+	}
+
+	s.reader.mu.Lock()
+	n := min(len(b), len(s.reader.buf))
+	s.reader.mu.Unlock()
+	return copy(b, s.reader.consume(n)), nil
+}
+
+// stdinInput is the input shared by the prompts reading from os.Stdin.
+var stdinInput = sync.OnceValue(func() *SharedInput { return NewSharedInput(os.Stdin) })
+
+// newInputReader returns the reader of a prompt's input, which is the reader of the SharedInput if the input is shared.
+// It also returns the source of the input, whose terminal capabilities are used by the prompt.
+func newInputReader(input io.Reader) (*inputReader, io.Reader) {
+	if input == nil || input == io.Reader(os.Stdin) {
+		input = stdinInput()
+	}
+	if shared, ok := input.(*SharedInput); ok {
+		return shared.reader, shared.reader.source
+	}
+	if file, ok := input.(*os.File); ok {
+		input = internals.OSTerminal{File: file}
+	}
+	return &inputReader{source: input}, input
+}
+
+// read starts reading a chunk of the source, unless a read is already in progress.
+// It must be called with mu held, and returns a channel closed once the read returns.
+func (r *inputReader) read() <-chan struct{} {
+	if r.reading != nil {
+		return r.reading
+	}
+
+	reading := make(chan struct{})
+	r.reading = reading
+	go func() {
+		chunk := make([]byte, 4096)
+		n, err := r.source.Read(chunk)
+
+		r.mu.Lock()
+		r.buf = append(r.buf, chunk[:n]...)
+		if err != nil {
+			r.err = err
+		}
+		r.reading = nil
+		r.mu.Unlock()
+		close(reading)
+	}()
+	return reading
+}
+
+// fill reads the source until the buffered bytes are ready, the source fails, stop is closed or the timeout expires.
+// A read abandoned by stop or the timeout keeps running, and its bytes are buffered for the next read.
+//
+// Parameters:
+//   - stop (<-chan struct{}): Abandons the read once closed (default: nil, never).
+//   - timeout (time.Duration): The time after which the read is abandoned (default: 0, never).
+//   - ready (func(buf []byte) bool): Reports whether the buffered bytes are enough.
+//
+// Returns:
+//   - error: nil if the bytes are ready, the error of the source, or errInputStopped if the read is abandoned.
+func (r *inputReader) fill(stop <-chan struct{}, timeout time.Duration, ready func(buf []byte) bool) error {
+	var expired <-chan time.Time
+	if timeout > 0 {
+		timer := time.NewTimer(timeout)
+		defer timer.Stop()
+		expired = timer.C
+	}
+
+	r.mu.Lock()
+	for !ready(r.buf) {
+		if r.err != nil {
+			// Buffered bytes are consumed before the error, which is only returned once, as bufio does
+			err := r.err
+			r.err = nil
+			r.mu.Unlock()
+			return err
+		}
+
+		reading := r.read()
+		r.mu.Unlock()
+		select {
+		case <-reading:
+		case <-stop:
+			return errInputStopped
+		case <-expired:
+			return errInputStopped
+		}
+		r.mu.Lock()
+	}
+	r.mu.Unlock()
+	return nil
+}
+
+// buffered reports whether bytes are buffered, with no need to read the source.
+func (r *inputReader) buffered() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.buf) > 0
+}
+
+// consume removes and returns the first n buffered bytes.
+func (r *inputReader) consume(n int) []byte {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	consumed := bytes.Clone(r.buf[:n])
+	r.buf = r.buf[n:]
+	if r.recording {
+		r.recorded = append(r.recorded, consumed...)
+	}
+	return consumed
+}
+
+// unread puts back consumed bytes, which are then read again first.
+func (r *inputReader) unread(b ...byte) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.buf = append(bytes.Clone(b), r.buf...)
+	if r.recording {
+		r.recorded = r.recorded[:max(len(r.recorded)-len(b), 0)]
+	}
+}
+
+// record starts recording the consumed bytes, e.g. the bytes of a key, so they can be put back with unread.
+// The recording stops once the returned function is called, which returns the recorded bytes.
+func (r *inputReader) record() (stop func() []byte) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.recorded = nil
+	r.recording = true
+	return func() []byte {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		recorded := r.recorded
+		r.recorded = nil
+		r.recording = false
+		return recorded
+	}
+}
+
+// readByte reads the next byte, waiting for it at most timeout (default: 0, until it is read).
+func (r *inputReader) readByte(stop <-chan struct{}, timeout time.Duration) (byte, error) {
+	if err := r.fill(stop, timeout, func(buf []byte) bool { return len(buf) > 0 }); err != nil {
+		return 0, err
+	}
+	return r.consume(1)[0], nil
+}
+
+// readRune reads the next UTF-8 encoded rune.
+// An invalid encoding is read as a single utf8.RuneError byte, as bufio.Reader.ReadRune does.
+func (r *inputReader) readRune(stop <-chan struct{}) (rune, int, error) {
+	err := r.fill(stop, 0, func(buf []byte) bool { return utf8.FullRune(buf) })
+	if err != nil && (err == errInputStopped || !r.buffered()) {
+		return 0, 0, err
+	}
+
+	r.mu.Lock()
+	char, size := utf8.DecodeRune(r.buf)
+	r.mu.Unlock()
+	r.consume(size)
+	return char, size, nil
+}
+
+// readString reads until the first occurrence of delim, which is included in the returned string.
+// If the source ends before delim, the remaining bytes are returned along with the error.
+func (r *inputReader) readString(delim byte, stop <-chan struct{}) (string, error) {
+	err := r.fill(stop, 0, func(buf []byte) bool { return bytes.IndexByte(buf, delim) >= 0 })
+	if err == errInputStopped {
+		return "", err
+	}
+
+	r.mu.Lock()
+	n := len(r.buf)
+	if index := bytes.IndexByte(r.buf, delim); index >= 0 {
+		n = index + 1
+	}
+	r.mu.Unlock()
+	return string(r.consume(n)), err
+}
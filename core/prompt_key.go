@@ -0,0 +1,379 @@
+package core
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+type KeyName string
+
+type Key struct {
+	Name  KeyName
+	Char  string
+	Shift bool
+	Ctrl  bool
+	Alt   bool
+	// Mouse holds the mouse event of a MouseKey
+	Mouse *Mouse
+	// cursorRow holds the row of a cursor position report, which is not a key press
+	cursorRow int
+	// kittyReport marks a report of the kitty keyboard protocol flags, which is not a key press
+	kittyReport bool
+}
+
+const (
+	UpKey        KeyName = "Up"
+	DownKey      KeyName = "Down"
+	LeftKey      KeyName = "Left"
+	RightKey     KeyName = "Right"
+	HomeKey      KeyName = "Home"
+	EndKey       KeyName = "End"
+	SpaceKey     KeyName = "Space"
+	EnterKey     KeyName = "Enter"
+	CancelKey    KeyName = "Cancel"
+	TabKey       KeyName = "Tab"
+	BackspaceKey KeyName = "Backspace"
+	EscapeKey    KeyName = "Escape"
+	DeleteKey    KeyName = "Delete"
+	InsertKey    KeyName = "Insert"
+	PageUpKey    KeyName = "PageUp"
+	PageDownKey  KeyName = "PageDown"
+	F1Key        KeyName = "F1"
+	F2Key        KeyName = "F2"
+	F3Key        KeyName = "F3"
+	F4Key        KeyName = "F4"
+	F5Key        KeyName = "F5"
+	F6Key        KeyName = "F6"
+	F7Key        KeyName = "F7"
+	F8Key        KeyName = "F8"
+	F9Key        KeyName = "F9"
+	F10Key       KeyName = "F10"
+	F11Key       KeyName = "F11"
+	F12Key       KeyName = "F12"
+	// PasteKey is a text pasted by the user at once, which is held in Key.Char.
+	PasteKey KeyName = "Paste"
+)
+
+// Binding returns the name under which the key is looked up in Settings.Aliases.
+// Modifiers are prefixed to the key name in the "Ctrl+Alt+Shift+" order, e.g. "Ctrl+Right", "Alt+b" or "Shift+Tab".
+// Keys without modifiers are bound by their name.
+func (k *Key) Binding() KeyName {
+	var binding strings.Builder
+	if k.Ctrl {
+		binding.WriteString("Ctrl+")
+	}
+	if k.Alt {
+		binding.WriteString("Alt+")
+	}
+	if k.Shift {
+		binding.WriteString("Shift+")
+	}
+	binding.WriteString(string(k.Name))
+	return KeyName(binding.String())
+}
+
+// ParseKey parses a rune into a Key.
+// Control characters are parsed as Ctrl modified keys, and escape sequences are read from the input.
+func (p *Prompt[TValue]) ParseKey(r rune) *Key {
+	return p.parseKey(r, nil)
+}
+
+// parseKey parses a rune into a Key like ParseKey, abandoning the reads of a bracketed paste once stop is closed.
+func (p *Prompt[TValue]) parseKey(r rune, stop <-chan struct{}) *Key {
+	switch r {
+	case '\r', '\n':
+		return &Key{Name: EnterKey}
+	case ' ':
+		return &Key{Name: SpaceKey}
+	case '\b', 127:
+		return &Key{Name: BackspaceKey}
+	case '\t':
+		return &Key{Name: TabKey}
+	case 3:
+		return &Key{Name: CancelKey}
+	case 0:
+		return &Key{Name: SpaceKey, Ctrl: true}
+	case 27:
+		return p.parseEscape(stop)
+	}
+
+	if r >= 1 && r <= 26 {
+		return &Key{Name: KeyName(rune('a' + r - 1)), Ctrl: true}
+	}
+	if r >= 28 && r <= 31 {
+		return &Key{Name: KeyName(rune('\\' + r - 28)), Ctrl: true}
+	}
+
+	char := string(r)
+	return &Key{Char: char, Name: KeyName(char)}
+}
+
+// sequenceByteTimeout is the time within which the next byte of an escape sequence is expected,
+// after which a lone escape is read as the Escape key.
+const sequenceByteTimeout = 50 * time.Millisecond
+
+// readSequenceByte reads the next byte of an escape sequence.
+// Escape sequences are written by the terminal at once, but may still be split across reads,
+// so a byte is considered part of the sequence if it is read within sequenceByteTimeout.
+func (p *Prompt[TValue]) readSequenceByte() (byte, bool) {
+	b, err := p.rl.readByte(nil, sequenceByteTimeout)
+	return b, err == nil
+}
+
+// parseEscape parses the input following an escape byte.
+// A lone escape is the Escape key, "ESC [" and "ESC O" introduce CSI and SS3 sequences,
+// and any other key following an escape is read as that key with the Alt modifier.
+func (p *Prompt[TValue]) parseEscape(stop <-chan struct{}) *Key {
+	next, ok := p.readSequenceByte()
+	if !ok {
+		return &Key{Name: EscapeKey}
+	}
+
+	switch next {
+	case '[':
+		return p.parseCSI(stop)
+	case 'O':
+		return p.parseSS3()
+	case 27:
+		return &Key{Name: EscapeKey, Alt: true}
+	}
+
+	p.rl.unread(next)
+	r, _, err := p.rl.readRune(stop)
+	if err != nil {
+		return &Key{}
+	}
+	key := p.parseKey(r, stop)
+	key.Char = ""
+	key.Alt = true
+	return key
+}
+
+// parseCSI parses a Control Sequence Introducer sequence ("ESC [").
+// The sequence is made of optional ";" separated parameters and a final byte,
+// e.g. "ESC [ A" for Up, "ESC [ 3 ~" for Delete or "ESC [ 1 ; 5 C" for Ctrl+Right.
+func (p *Prompt[TValue]) parseCSI(stop <-chan struct{}) *Key {
+	first, ok := p.readSequenceByte()
+	if !ok {
+		return &Key{Name: "[", Alt: true}
+	}
+
+	// Linux console function keys: "ESC [ [ A" to "ESC [ [ E"
+	if first == '[' {
+		next, ok := p.readSequenceByte()
+		if !ok || next < 'A' || next > 'E' {
+			return &Key{}
+		}
+		return &Key{Name: KeyName(fmt.Sprintf("F%d", next-'A'+1))}
+	}
+
+	var params strings.Builder
+	final := first
+	for final < 0x40 || final > 0x7e {
+		params.WriteByte(final)
+		if final, ok = p.readSequenceByte(); !ok {
+			return &Key{}
+		}
+	}
+
+	if strings.HasPrefix(params.String(), "<") && (final == 'M' || final == 'm') {
+		return parseMouse(strings.TrimPrefix(params.String(), "<"), final)
+	}
+
+	// The "CSI ? flags u" report answers a kitty keyboard query, and is never sent for a key press
+	if final == 'u' && strings.HasPrefix(params.String(), "?") {
+		return &Key{kittyReport: true}
+	}
+
+	// Parameters may carry ":" separated sub-parameters, e.g. "ESC [ 97 ; 5 : 3 u" for a key release
+	fields := strings.Split(params.String(), ";")
+	code, _ := strconv.Atoi(strings.Split(fields[0], ":")[0])
+	modifier, event := 1, 1
+	if len(fields) > 1 {
+		subfields := strings.Split(fields[1], ":")
+		modifier, _ = strconv.Atoi(subfields[0])
+		if len(subfields) > 1 {
+			event, _ = strconv.Atoi(subfields[1])
+		}
+	}
+
+	if final == 'R' {
+		if key := p.parseCursorReport(fields); key != nil {
+			return key
+		}
+	}
+
+	var key *Key
+	switch final {
+	case '~':
+		if code == 200 {
+			return p.readPaste(stop)
+		}
+		key = &Key{Name: tildeKeys[code]}
+	case 'u':
+		if event == kittyReleaseEvent {
+			return &Key{}
+		}
+		return parseKittyKey(code, modifier)
+	case 'Z':
+		key = &Key{Name: TabKey, Shift: true}
+	default:
+		key = &Key{Name: finalKeys[final]}
+	}
+
+	if key.Name == "" {
+		return &Key{}
+	}
+	applyKeyModifier(key, modifier)
+	return key
+}
+
+// parseKittyKey parses a "CSI code ; modifier u" key reported by the kitty keyboard protocol.
+// The code is the unicode code point of the unshifted key, so chords as Ctrl+I and Tab or Ctrl+Enter and Enter are told apart.
+func parseKittyKey(code int, modifier int) *Key {
+	var key *Key
+	switch code {
+	case 13, kittyKeypadEnter:
+		key = &Key{Name: EnterKey}
+	case 9:
+		key = &Key{Name: TabKey}
+	case 8, 127:
+		key = &Key{Name: BackspaceKey}
+	case 27:
+		key = &Key{Name: EscapeKey}
+	case 32:
+		key = &Key{Name: SpaceKey}
+	default:
+		// Control codes and kitty's private use functional keys have no name
+		if code < 32 || (code >= 0xe000 && code <= 0xf8ff) || code > unicode.MaxRune {
+			return &Key{}
+		}
+		char := string(rune(code))
+		key = &Key{Name: KeyName(char), Char: char}
+	}
+
+	applyKeyModifier(key, modifier)
+	if key.Ctrl || key.Alt {
+		key.Char = ""
+	}
+	if key.Name == "c" && key.Ctrl && !key.Alt && !key.Shift {
+		return &Key{Name: CancelKey}
+	}
+	return key
+}
+
+// readPaste reads a bracketed paste, from after its "ESC [ 200 ~" start until the "ESC [ 201 ~" end sequence.
+// Unlike other sequences, a paste may exceed the buffered input, so it is read until the end sequence, the input ends
+// or stop is closed, e.g. once the prompt is done while the end sequence is lost, and the text read so far is returned.
+func (p *Prompt[TValue]) readPaste(stop <-chan struct{}) *Key {
+	var text strings.Builder
+	for {
+		b, err := p.rl.readByte(stop, 0)
+		if err != nil {
+			break
+		}
+		text.WriteByte(b)
+		if b == '~' && strings.HasSuffix(text.String(), bracketedPasteEnd) {
+			break
+		}
+	}
+	return &Key{Name: PasteKey, Char: strings.TrimSuffix(text.String(), bracketedPasteEnd)}
+}
+
+// parseSS3 parses a Single Shift Three sequence ("ESC O").
+// It is sent by some terminals for arrows, Home, End and F1 to F4, e.g. "ESC O A" for Up.
+// The final byte may be preceded by a modifier, e.g. "ESC O 5 P" for Ctrl+F1.
+func (p *Prompt[TValue]) parseSS3() *Key {
+	final, ok := p.readSequenceByte()
+	if !ok {
+		return &Key{Name: "O", Alt: true}
+	}
+
+	modifier := 1
+	if final >= '0' && final <= '9' {
+		modifier = int(final - '0')
+		if final, ok = p.readSequenceByte(); !ok {
+			return &Key{}
+		}
+	}
+
+	key := &Key{Name: finalKeys[final]}
+	if final == 'M' {
+		key.Name = EnterKey
+	}
+	if key.Name == "" {
+		return &Key{}
+	}
+	applyKeyModifier(key, modifier)
+	return key
+}
+
+// applyKeyModifier applies an xterm modifier parameter to a key.
+// The parameter is 1 plus a bitmask of Shift (1), Alt (2), Ctrl (4), Super (8), Hyper (16), Meta (32), Caps Lock (64) and Num Lock (128).
+// Super and Meta are read as Alt, while Hyper and the lock states are ignored.
+func applyKeyModifier(key *Key, modifier int) {
+	mask := max(modifier-1, 0)
+	key.Shift = key.Shift || mask&1 != 0
+	key.Alt = key.Alt || mask&2 != 0 || mask&8 != 0 || mask&32 != 0
+	key.Ctrl = key.Ctrl || mask&4 != 0
+}
+
+const (
+	// bracketedPasteEnable makes the terminal wrap pasted text in "ESC [ 200 ~" and "ESC [ 201 ~".
+	bracketedPasteEnable = "\x1b[?2004h"
+	// bracketedPasteDisable disables the bracketed paste mode.
+	bracketedPasteDisable = "\x1b[?2004l"
+	// bracketedPasteEnd is the sequence ending a bracketed paste.
+	bracketedPasteEnd = "\x1b[201~"
+	// kittyKeyboardQuery requests the current flags of the kitty keyboard protocol, only answered by terminals supporting it.
+	kittyKeyboardQuery = "\x1b[?u"
+	// kittyKeyboardEnable pushes the "disambiguate escape codes" flag of the kitty keyboard protocol.
+	kittyKeyboardEnable = "\x1b[>1u"
+	// kittyKeyboardDisable pops the flags pushed by kittyKeyboardEnable, restoring the previous mode.
+	kittyKeyboardDisable = "\x1b[<u"
+	// kittyReleaseEvent is the event type of key releases.
+	kittyReleaseEvent = 3
+	// kittyKeypadEnter is the code of the keypad Enter key.
+	kittyKeypadEnter = 57414
+)
+
+// finalKeys maps the final byte of CSI and SS3 sequences to their keys.
+var finalKeys = map[byte]KeyName{
+	'A': UpKey,
+	'B': DownKey,
+	'C': RightKey,
+	'D': LeftKey,
+	'H': HomeKey,
+	'F': EndKey,
+	'P': F1Key,
+	'Q': F2Key,
+	'R': F3Key,
+	'S': F4Key,
+}
+
+// tildeKeys maps the code of "ESC [ code ~" sequences to their keys.
+var tildeKeys = map[int]KeyName{
+	1:  HomeKey,
+	2:  InsertKey,
+	3:  DeleteKey,
+	4:  EndKey,
+	5:  PageUpKey,
+	6:  PageDownKey,
+	7:  HomeKey,
+	8:  EndKey,
+	11: F1Key,
+	12: F2Key,
+	13: F3Key,
+	14: F4Key,
+	15: F5Key,
+	17: F6Key,
+	18: F7Key,
+	19: F8Key,
+	20: F9Key,
+	21: F10Key,
+	23: F11Key,
+	24: F12Key,
+}
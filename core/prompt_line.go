@@ -0,0 +1,191 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// runLineMode runs the prompt without raw mode or cursor movements.
+// It writes the rendered question, reads a whole line as the answer, parses it with ParseLine and validates it
+// with Validate and LiveValidate. If the answer is invalid, the error is rendered and the question is asked again.
+// The prompt is cancelled once its input ends or its context is done.
+// As the keys are not read one at a time, the Timeout is only stopped once the first line is read.
+func (p *Prompt[TValue]) runLineMode() (TValue, error) {
+	ctx, stopTimeout := p.context, context.CancelFunc(func() {})
+	if p.Timeout > 0 {
+		ctx, stopTimeout = context.WithTimeout(p.context, p.Timeout)
+	}
+	defer stopTimeout()
+
+	for {
+		p.write(p.markOptionLines(p.Render(p)))
+
+		line, err := p.readLine(ctx.Done())
+		if err != nil {
+			if p.context.Err() == nil && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				return p.timeOutLineMode()
+			}
+			return p.cancelLineMode(err)
+		}
+		stopTimeout()
+		ctx = p.context
+
+		value, err := p.parseLine(line)
+		if err == nil {
+			previous := p.Value
+			p.Value = value
+			p.validate(func(validationErr error) { err = validationErr })
+			if err != nil {
+				p.Value = previous
+			}
+		}
+		if err != nil {
+			p.State = ErrorState
+			p.Error = err.Error()
+			p.Emit(ErrorEvent, err)
+			continue
+		}
+
+		return p.submitLineMode()
+	}
+}
+
+// submitLineMode submits the current value of the prompt in line mode.
+func (p *Prompt[TValue]) submitLineMode() (TValue, error) {
+	p.Error = ""
+	p.State = SubmitState
+	p.Emit(FinalizeEvent)
+	p.Emit(SubmitEvent, p.Value)
+	return p.Value, nil
+}
+
+// cancelLineMode cancels the prompt in line mode, once its input fails or its context is done.
+// The end of the input and the context are reported as ErrCancelPrompt, while other errors, such as ErrTimeout, are returned as is.
+func (p *Prompt[TValue]) cancelLineMode(err error) (TValue, error) {
+	p.write("\n")
+	p.State = CancelState
+	p.Emit(FinalizeEvent)
+	p.Emit(CancelEvent)
+
+	if errors.Is(err, io.EOF) || errors.Is(err, errInputStopped) {
+		return p.Value, ErrCancelPrompt
+	}
+	return p.Value, err
+}
+
+// timeOutLineMode ends the prompt in line mode once its Timeout is over, as timeOut does.
+// The current value is validated before being submitted, and the prompt is cancelled with ErrTimeout if it is invalid.
+func (p *Prompt[TValue]) timeOutLineMode() (TValue, error) {
+	if !p.TimeoutCancel {
+		var err error
+		p.validate(func(validationErr error) { err = validationErr })
+		if err == nil {
+			p.write("\n")
+			return p.submitLineMode()
+		}
+	}
+	return p.cancelLineMode(ErrTimeout)
+}
+
+// readLine reads a single line from the input, without its line ending, until stop is closed.
+// The last line of the input is accepted even if it is not terminated by a line break.
+func (p *Prompt[TValue]) readLine(stop <-chan struct{}) (string, error) {
+	line, err := p.rl.readString('\n', stop)
+	if err != nil && (!errors.Is(err, io.EOF) || line == "") {
+		return "", err
+	}
+	p.write("\n")
+	return strings.TrimRight(line, "\r\n"), nil
+}
+
+// parseLine parses a line answer into a value.
+// If no ParseLine function is set, string prompts take the line as is.
+func (p *Prompt[TValue]) parseLine(line string) (TValue, error) {
+	if p.ParseLine != nil {
+		return p.ParseLine(line)
+	}
+	if value, ok := any(line).(TValue); ok {
+		return value, nil
+	}
+	return p.Value, errors.New("Line answers are not supported by this prompt.")
+}
+
+// splitLineList splits a comma-separated line answer into its trimmed, non-empty items.
+func splitLineList(line string) []string {
+	var items []string
+	for _, item := range strings.Split(line, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// matchLineOption returns the index of the option that matches a line answer, or -1 if none matches.
+// An answer matches an option by its label, its value (both case-insensitive) or its 1-based position.
+func matchLineOption(answer string, labels []string, values []string) int {
+	answer = strings.TrimSpace(answer)
+	for i := range labels {
+		if strings.EqualFold(answer, labels[i]) || (i < len(values) && strings.EqualFold(answer, values[i])) {
+			return i
+		}
+	}
+	if position, err := strconv.Atoi(answer); err == nil && position >= 1 && position <= len(labels) {
+		return position - 1
+	}
+	return -1
+}
+
+// checkLinePath checks that a path answered in line mode exists, and is a directory if onlyDir is set.
+// A leading "~" is expanded to the home directory of the user.
+func checkLinePath(fs FileSystem, path string, onlyDir bool) error {
+	name := path
+	if strings.HasPrefix(name, "~") {
+		if homeDir, err := fs.UserHomeDir(); err == nil {
+			name = strings.Replace(name, "~", homeDir, 1)
+		}
+	}
+
+	exists, isDir := statLinePath(fs, filepath.Clean(name))
+	if !exists {
+		return fmt.Errorf("Path does not exist: %s", path)
+	}
+	if onlyDir && !isDir {
+		return fmt.Errorf("Path is not a directory: %s", path)
+	}
+	return nil
+}
+
+// statLinePath reports whether a path exists and is a directory.
+// File systems without the StatFileSystem capability are checked by reading the parent directory of the path.
+func statLinePath(fs FileSystem, name string) (exists bool, isDir bool) {
+	if statFS, ok := fs.(StatFileSystem); ok {
+		info, err := statFS.Stat(name)
+		if err != nil {
+			return false, false
+		}
+		return true, info.IsDir()
+	}
+
+	parent := filepath.Dir(name)
+	if parent == name {
+		// The root has no parent directory to be listed in
+		_, err := fs.ReadDir(name)
+		return err == nil, err == nil
+	}
+	entries, err := fs.ReadDir(parent)
+	if err != nil {
+		return false, false
+	}
+	for _, entry := range entries {
+		if entry.Name() == filepath.Base(name) {
+			return true, entry.IsDir()
+		}
+	}
+	return false, false
+}
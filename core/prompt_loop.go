@@ -0,0 +1,187 @@
+package core
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// eventLoop delivers the events of a running prompt to the single goroutine owning its state.
+// Keys, timers, validation results, terminal resizes and context cancellation are all handled as messages,
+// one at a time, so listeners and render functions never run concurrently.
+type eventLoop struct {
+	messages chan func()
+	running  atomic.Bool
+	// stopped is closed once the loop exits, releasing the goroutines waiting to deliver a message
+	stopped chan struct{}
+	// cursorReportPending is set while a cursor position report is expected, see parseCursorReport
+	cursorReportPending atomic.Bool
+	// kittyReportPending is set while the answer to the kitty keyboard query is expected, see enableInputModes
+	kittyReportPending atomic.Bool
+	// kittyKeyboardPushed is set once the kitty keyboard flags are pushed, so they are popped when the terminal is restored
+	kittyKeyboardPushed atomic.Bool
+}
+
+func newEventLoop() *eventLoop {
+	return &eventLoop{messages: make(chan func())}
+}
+
+// isRunning reports whether the event loop of the prompt is running.
+func (p *Prompt[TValue]) isRunning() bool {
+	return p.loop != nil && p.loop.running.Load()
+}
+
+// post delivers a message to the event loop, from another goroutine (e.g. a timer or a validation).
+// Messages posted while the prompt is not running are dropped.
+func (p *Prompt[TValue]) post(handle func()) {
+	if !p.isRunning() {
+		return
+	}
+	select {
+	case p.loop.messages <- handle:
+	case <-p.loop.stopped:
+	}
+}
+
+// dispatch handles an event on the event loop and waits until it is handled.
+// The handler calls done once the event is fully handled, which may happen in a later message (e.g. after a validation).
+// If the prompt is not running, the event is handled right away by the caller.
+// It must not be called from listeners, which already run on the event loop.
+func (p *Prompt[TValue]) dispatch(handle func(done func())) {
+	if !p.isRunning() {
+		handle(func() {})
+		return
+	}
+
+	handled := make(chan struct{})
+	done := sync.OnceFunc(func() { close(handled) })
+	select {
+	case p.loop.messages <- func() { handle(done) }:
+	case <-p.loop.stopped:
+		return
+	}
+	select {
+	case <-handled:
+	case <-p.loop.stopped:
+	}
+}
+
+// readKeys reads and parses a key from the input each time one is requested, until the requests are closed.
+// Keys are only read on demand, so the input typed after the prompt is done is left to the next prompt.
+// Once stop is closed, the pending read is abandoned, and a key read but not handled yet is put back to the input,
+// so the next prompt reads it instead.
+// The keys channel is closed if the input fails, e.g. once it reaches its end.
+func (p *Prompt[TValue]) readKeys(stop <-chan struct{}, requests <-chan struct{}, keys chan<- *Key) {
+	defer close(keys)
+	for range requests {
+		key, raw, err := p.readKey(stop)
+		if err != nil {
+			return
+		}
+		select {
+		case keys <- key:
+		case <-stop:
+			p.rl.unread(raw...)
+			return
+		}
+	}
+}
+
+// readKey reads and parses the next key from the input, until stop is closed.
+// It returns the key along with the raw bytes it was parsed from.
+func (p *Prompt[TValue]) readKey(stop <-chan struct{}) (*Key, []byte, error) {
+	recorded := p.rl.record()
+	for {
+		r, size, err := p.rl.readRune(stop)
+		if err != nil {
+			return nil, recorded(), err
+		}
+		if size > 0 {
+			key := p.parseKey(r, stop)
+			return key, recorded(), nil
+		}
+	}
+}
+
+// runLoop runs the event loop until the prompt is submitted or cancelled.
+//
+// Parameters:
+//   - restore (func() error): The function restoring the terminal from raw mode, or nil if it is not in raw mode.
+func (p *Prompt[TValue]) runLoop(restore func() error) {
+	p.loop.stopped = make(chan struct{})
+
+	// The keys are unbuffered, so a key read once the loop is done is never sent, but put back to the input,
+	// and the loop waits for the reader to return, so the next prompt does not read the input concurrently.
+	requests, keys := make(chan struct{}, 1), make(chan *Key)
+	read := make(chan struct{})
+	go func() {
+		defer close(read)
+		p.readKeys(p.loop.stopped, requests, keys)
+	}()
+	defer func() { <-read }()
+	defer close(requests)
+
+	resized, stopResize := p.watchResize()
+	defer stopResize()
+
+	p.loop.running.Store(true)
+	defer func() {
+		p.loop.running.Store(false)
+		close(p.loop.stopped)
+	}()
+
+	if p.Mouse {
+		p.requestFrameRow()
+	}
+	p.startTimeout()
+	p.render()
+
+	isDone := func() bool { return p.State == SubmitState || p.State == CancelState }
+	requested, typedAhead := false, false
+	for !isDone() {
+		// Keys are still read while a submitted value is validated, so it can be cancelled, see pressKey.
+		// The input typed ahead of the submission is already buffered, and is left to the next prompt instead.
+		if !requested && keys != nil && (!p.IsValidating || !typedAhead) {
+			requests <- struct{}{}
+			requested = true
+		}
+
+		select {
+		case key, ok := <-keys:
+			requested = false
+			if !ok {
+				keys = nil
+				continue
+			}
+			validating := p.IsValidating
+			p.handleInput(key)
+			if !validating {
+				typedAhead = p.IsValidating && p.rl.buffered()
+			}
+		case handle := <-p.loop.messages:
+			handle()
+		case <-resized:
+			p.redraw()
+		case <-p.context.Done():
+			// Restore terminal immediately when context is cancelled
+			if restore != nil {
+				restore()
+			}
+			p.cancel()
+		}
+	}
+}
+
+// handleInput handles a key read from the input.
+func (p *Prompt[TValue]) handleInput(key *Key) {
+	switch {
+	case key.cursorRow > 0:
+		p.frameRow = key.cursorRow
+		p.trackFrameRow()
+	case key.kittyReport:
+		p.pushKittyKeyboard()
+	case key.Mouse != nil:
+		p.pressMouse(key.Mouse)
+	default:
+		p.pressKey(key, func() {})
+	}
+}
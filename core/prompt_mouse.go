@@ -0,0 +1,261 @@
+package core
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/orochaa/go-clack/core/utils"
+)
+
+// MouseButton represents the button of a mouse event.
+type MouseButton int
+
+const (
+	LeftButton MouseButton = iota
+	MiddleButton
+	RightButton
+	// NoButton is reported when the mouse moves without a pressed button
+	NoButton
+	WheelUpButton
+	WheelDownButton
+)
+
+// Mouse represents a mouse event reported by the terminal.
+type Mouse struct {
+	Button MouseButton
+	// Row and Column are the 1-based terminal coordinates of the event
+	Row    int
+	Column int
+	// Release is set when the button is released instead of pressed
+	Release bool
+	// Motion is set when the mouse moves instead of clicking
+	Motion bool
+	Shift  bool
+	Ctrl   bool
+	Alt    bool
+}
+
+// MouseKey is a mouse event, which is held in Key.Mouse.
+const MouseKey KeyName = "Mouse"
+
+const (
+	// mouseEnable enables the reporting of mouse clicks and wheel events, encoded as SGR sequences.
+	mouseEnable = "\x1b[?1000h\x1b[?1006h"
+	// mouseDisable disables the mouse reporting.
+	mouseDisable = "\x1b[?1006l\x1b[?1000l"
+	// cursorPositionRequest requests the terminal to report the cursor position as "ESC [ row ; column R".
+	cursorPositionRequest = "\x1b[6n"
+)
+
+// optionLine is the frame line of an option rendered by LimitLines, by its index in the limited lines.
+type optionLine struct {
+	index int
+	line  int
+}
+
+// optionMarkerPrefix starts the zero-width OSC sequence marking the last line of each option rendered by LimitLines,
+// e.g. "ESC ] clack-option ; 2 BEL" for the third line, which is stripped from the frame before it is written, see markOptionLines.
+const optionMarkerPrefix = "\x1b]clack-option;"
+
+// optionMarker returns the marker of the option at the given index of the limited lines.
+func optionMarker(index int) string {
+	return optionMarkerPrefix + strconv.Itoa(index) + "\a"
+}
+
+// parseMouse parses the parameters of a SGR mouse sequence, "ESC [ < button ; column ; row M",
+// which ends with "m" instead of "M" when the button is released.
+//
+// Parameters:
+//   - params (string): The parameters of the sequence, without the "<" prefix.
+//   - final (byte): The final byte of the sequence.
+//
+// Returns:
+//   - *Key: A MouseKey holding the mouse event, or an empty key if the sequence is invalid.
+func parseMouse(params string, final byte) *Key {
+	fields := strings.Split(params, ";")
+	if len(fields) != 3 {
+		return &Key{}
+	}
+	code, err1 := strconv.Atoi(fields[0])
+	column, err2 := strconv.Atoi(fields[1])
+	row, err3 := strconv.Atoi(fields[2])
+	if err1 != nil || err2 != nil || err3 != nil {
+		return &Key{}
+	}
+
+	mouse := &Mouse{
+		Row:     row,
+		Column:  column,
+		Release: final == 'm',
+		Motion:  code&32 != 0,
+		Shift:   code&4 != 0,
+		Alt:     code&8 != 0,
+		Ctrl:    code&16 != 0,
+	}
+	switch {
+	case code&128 != 0:
+		return &Key{}
+	case code&64 != 0:
+		mouse.Button = WheelUpButton + MouseButton(code&1)
+	default:
+		mouse.Button = MouseButton(code & 3)
+	}
+	return &Key{Name: MouseKey, Mouse: mouse}
+}
+
+// parseCursorReport parses a "ESC [ row ; column R" cursor position report, which holds the row of the frame.
+// Reports are only expected after a request, as the sequence is ambiguous with modified F3 keys.
+// The row is recorded by the event loop, as the report is parsed while reading the input.
+//
+// Parameters:
+//   - fields ([]string): The parameters of the sequence.
+//
+// Returns:
+//   - *Key: A key holding the reported row, or nil if the sequence is not a cursor position report.
+func (p *Prompt[TValue]) parseCursorReport(fields []string) *Key {
+	if p.loop == nil || len(fields) != 2 {
+		return nil
+	}
+	row, err := strconv.Atoi(fields[0])
+	if err != nil || row <= 0 || !p.loop.cursorReportPending.CompareAndSwap(true, false) {
+		return nil
+	}
+	return &Key{cursorRow: row}
+}
+
+// requestFrameRow requests the terminal to report the cursor position, which is where the frame starts.
+func (p *Prompt[TValue]) requestFrameRow() {
+	p.loop.cursorReportPending.Store(true)
+	p.write(cursorPositionRequest)
+}
+
+// trackFrameRow keeps the frame row up to date once the terminal has scrolled to fit the frame.
+func (p *Prompt[TValue]) trackFrameRow() {
+	_, height, err := p.Size()
+	if p.frameRow == 0 || err != nil {
+		return
+	}
+	rows := utils.CountRows(p.Frame, p.width())
+	if p.frameRow+rows-1 > height {
+		p.frameRow = max(height-rows+1, 1)
+	}
+}
+
+// PressMouse handles mouse events and updates the state of the prompt.
+// While the prompt runs, the event is handled by its event loop, and PressMouse returns once it is handled.
+func (p *Prompt[TValue]) PressMouse(mouse *Mouse) {
+	p.dispatch(func(done func()) {
+		p.pressMouse(mouse)
+		done()
+	})
+}
+
+// pressMouse handles a mouse event on the event loop.
+// Mouse events are ignored while a submitted value is validated.
+func (p *Prompt[TValue]) pressMouse(mouse *Mouse) {
+	if p.IsValidating {
+		return
+	}
+	p.stopTimeout()
+	if p.State == InitialState || p.State == ErrorState {
+		p.State = ActiveState
+	}
+
+	p.Emit(MouseEvent, mouse)
+	p.scheduleLiveValidation()
+
+	p.render()
+}
+
+// OptionAt returns the index of the line rendered by LimitLines at the row of a mouse event.
+// The options are located by the frame lines recorded while rendering them, which requires the terminal to have reported its position.
+//
+// Parameters:
+//   - mouse (*Mouse): The mouse event.
+//
+// Returns:
+//   - index (int): The index of the line in the lines passed to LimitLines.
+//   - ok (bool): Whether a line is rendered at the row of the mouse event.
+func (p *Prompt[TValue]) OptionAt(mouse *Mouse) (index int, ok bool) {
+	if p.frameRow == 0 {
+		return 0, false
+	}
+	target := p.frameLineAt(mouse.Row)
+	if target < 0 {
+		return 0, false
+	}
+
+	// Lines may be preceded by spacing lines, so they are only clicked on their last line
+	for _, option := range p.optionLines {
+		if option.line == target {
+			return option.index, true
+		}
+	}
+	return 0, false
+}
+
+// markOptionLines strips the option markers of LimitLines from a rendered frame,
+// recording the frame line of each marked option, so mouse events can be mapped back to them.
+// The lines are located once the frame is decorated, wrapped and styled, e.g. by FormatLines.
+func (p *Prompt[TValue]) markOptionLines(frame string) string {
+	p.optionLines = p.optionLines[:0]
+	if !strings.Contains(frame, optionMarkerPrefix) {
+		return frame
+	}
+
+	var stripped strings.Builder
+	for {
+		start := strings.Index(frame, optionMarkerPrefix)
+		if start < 0 {
+			break
+		}
+		end := strings.IndexByte(frame[start:], '\a')
+		if end < 0 {
+			break
+		}
+		stripped.WriteString(frame[:start])
+		if index, err := strconv.Atoi(frame[start+len(optionMarkerPrefix) : start+end]); err == nil {
+			line := len(utils.SplitLines(stripped.String())) - 1
+			p.optionLines = append(p.optionLines, optionLine{index: index, line: line})
+		}
+		frame = frame[start+end+1:]
+	}
+	stripped.WriteString(frame)
+	return stripped.String()
+}
+
+// frameLineAt returns the index of the frame line rendered at a terminal row, or -1 if it is outside of the frame.
+// Lines wrapped by the terminal take many rows.
+func (p *Prompt[TValue]) frameLineAt(row int) int {
+	width := p.width()
+	row -= p.frameRow
+	for i, line := range utils.SplitLines(p.Frame) {
+		if row < 0 {
+			break
+		}
+		if row -= utils.CountRows(line, width); row < 0 {
+			return i
+		}
+	}
+	return -1
+}
+
+// handleMouse handles the mouse events of list prompts.
+// The wheel moves the cursor by one option, scrolling the window of LimitLines, and a left click on a rendered option selects it.
+//
+// Parameters:
+//   - mouse (*Mouse): The mouse event to handle.
+//   - moveCursor (func(direction int)): Moves the cursor up or down (-1 for up, 1 for down).
+//   - clickOption (func(index int)): Selects the option at the given index of the lines passed to LimitLines.
+func (p *Prompt[TValue]) handleMouse(mouse *Mouse, moveCursor func(direction int), clickOption func(index int)) {
+	switch {
+	case mouse.Button == WheelUpButton:
+		moveCursor(-1)
+	case mouse.Button == WheelDownButton:
+		moveCursor(1)
+	case mouse.Button == LeftButton && !mouse.Release && !mouse.Motion:
+		if index, ok := p.OptionAt(mouse); ok {
+			clickOption(index)
+		}
+	}
+}
@@ -0,0 +1,22 @@
+package core
+
+// defaultPageSize is the number of options of a page before the options are rendered by LimitLines.
+const defaultPageSize = 10
+
+// pageSize returns the number of options rendered by LimitLines, which is the size of a page of options.
+func (p *Prompt[TValue]) pageSize() int {
+	if p.visibleOptions == 0 {
+		return defaultPageSize
+	}
+	return p.visibleOptions
+}
+
+// halfPage returns the number of options moved by the half-page actions.
+func (p *Prompt[TValue]) halfPage() int {
+	return max(p.pageSize()/2, 1)
+}
+
+// clampIndex moves an index by an offset, stopping at the first and last indexes instead of wrapping around.
+func clampIndex(index, offset, length int) int {
+	return max(min(index+offset, length-1), 0)
+}
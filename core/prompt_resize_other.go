@@ -0,0 +1,49 @@
+//go:build !unix
+
+package core
+
+import "time"
+
+// resizePollInterval is the interval between terminal size checks, on platforms without SIGWINCH.
+const resizePollInterval = 250 * time.Millisecond
+
+// watchResize notifies when the terminal is resized.
+// There is no SIGWINCH signal outside of unix systems, so the terminal size is polled instead.
+//
+// Returns:
+//   - resized (<-chan struct{}): A channel receiving a value after each resize.
+//   - stop (func()): A function that stops watching the terminal.
+func (p *Prompt[TValue]) watchResize() (resized <-chan struct{}, stop func()) {
+	notifications := make(chan struct{}, 1)
+	width, height, err := p.Size()
+	if err != nil {
+		return notifications, func() {}
+	}
+
+	ticker := time.NewTicker(resizePollInterval)
+	quit := make(chan struct{})
+
+	go func() {
+		for {
+			select {
+			case <-quit:
+				return
+			case <-ticker.C:
+				newWidth, newHeight, err := p.Size()
+				if err != nil || (newWidth == width && newHeight == height) {
+					continue
+				}
+				width, height = newWidth, newHeight
+				select {
+				case notifications <- struct{}{}:
+				default:
+				}
+			}
+		}
+	}()
+
+	return notifications, func() {
+		ticker.Stop()
+		close(quit)
+	}
+}
@@ -0,0 +1,44 @@
+//go:build unix
+
+package core
+
+import (
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// watchResize notifies when the terminal is resized, which is signaled by SIGWINCH.
+//
+// Returns:
+//   - resized (<-chan struct{}): A channel receiving a value after each resize.
+//   - stop (func()): A function that stops watching the terminal.
+func (p *Prompt[TValue]) watchResize() (resized <-chan struct{}, stop func()) {
+	notifications := make(chan struct{}, 1)
+	if _, ok := p.output.(SizedTerminal); !ok {
+		return notifications, func() {}
+	}
+
+	signals := make(chan os.Signal, 1)
+	quit := make(chan struct{})
+	signal.Notify(signals, syscall.SIGWINCH)
+
+	go func() {
+		for {
+			select {
+			case <-quit:
+				return
+			case <-signals:
+				select {
+				case notifications <- struct{}{}:
+				default:
+				}
+			}
+		}
+	}()
+
+	return notifications, func() {
+		signal.Stop(signals)
+		close(quit)
+	}
+}
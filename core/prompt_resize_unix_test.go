@@ -0,0 +1,36 @@
+//go:build unix
+
+package core_test
+
+import (
+	"io"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/orochaa/go-clack/core"
+	"github.com/orochaa/go-clack/third_party/sisteransi"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedrawOnResize(t *testing.T) {
+	input, writer := io.Pipe()
+	output := &MockTerminal{Width: 80, Height: 40}
+	p := core.NewPrompt(core.PromptParams[string]{
+		Input:  input,
+		Output: output,
+		Render: func(p *core.Prompt[string]) string { return "foo\r\nbar" },
+	})
+
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		syscall.Kill(syscall.Getpid(), syscall.SIGWINCH)
+		time.Sleep(10 * time.Millisecond)
+		writer.Write([]byte("\r"))
+	}()
+	_, err := p.Run()
+	assert.NoError(t, err)
+
+	expected := sisteransi.MoveCursor(-1, -999) + sisteransi.EraseDown() + "foo\r\nbar"
+	assert.Contains(t, output.String(), expected)
+}
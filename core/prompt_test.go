@@ -1,14 +1,19 @@
 package core_test
 
 import (
+	"bytes"
+	"context"
 	"errors"
 	"fmt"
+	"io"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/orochaa/go-clack/core"
 	"github.com/orochaa/go-clack/third_party/picocolors"
+	"github.com/orochaa/go-clack/third_party/sisteransi"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -80,13 +85,23 @@ func TestEmitEventOnce(t *testing.T) {
 
 	p.Once(core.KeyEvent, func(args ...any) {
 		calledTimes++
+		assert.Equal(t, []any{"foo", 1}, args)
+		// A nested emission must not call the listener again
+		p.Emit(core.KeyEvent, "foo", 1)
 	})
-	p.Emit(core.KeyEvent)
+	p.Emit(core.KeyEvent, "foo", 1)
+	p.Emit(core.KeyEvent, "foo", 1)
+	assert.Equal(t, 1, calledTimes)
+
+	unsubscribe := p.Once(core.KeyEvent, func(args ...any) {
+		calledTimes++
+	})
+	unsubscribe()
 	p.Emit(core.KeyEvent)
 	assert.Equal(t, 1, calledTimes)
 }
 
-func TestEmitUnsubscribedEvent(t *testing.T) {
+func TestEmitOffEvent(t *testing.T) {
 	p := newPrompt()
 	calledTimes := 0
 	listener := func(args ...any) {
@@ -99,6 +114,131 @@ func TestEmitUnsubscribedEvent(t *testing.T) {
 	assert.Equal(t, 0, calledTimes)
 }
 
+func TestEmitUnsubscribedEvent(t *testing.T) {
+	p := newPrompt()
+	calledTimes := 0
+	listener := func(args ...any) {
+		calledTimes++
+	}
+
+	unsubscribe := p.On(core.KeyEvent, listener)
+	unsubscribe()
+	p.Emit(core.KeyEvent)
+	assert.Equal(t, 0, calledTimes)
+}
+
+func TestUnsubscribeClosures(t *testing.T) {
+	p := newPrompt()
+	calls := []string{}
+	newListener := func(name string) core.EventListener {
+		return func(args ...any) {
+			calls = append(calls, name)
+		}
+	}
+
+	unsubscribeFoo := p.On(core.KeyEvent, newListener("foo"))
+	p.On(core.KeyEvent, newListener("bar"))
+	unsubscribeFoo()
+	unsubscribeFoo()
+	p.Emit(core.KeyEvent)
+	assert.Equal(t, []string{"bar"}, calls)
+}
+
+func TestTypedListeners(t *testing.T) {
+	err := errors.New("invalid")
+	p := core.NewPrompt(core.PromptParams[string]{
+		InitialValue: "foo",
+		Validate: func(value string) error {
+			if value == "foo" {
+				return err
+			}
+			return nil
+		},
+		Render: func(p *core.Prompt[string]) string { return "" },
+	})
+
+	var keys []*core.Key
+	var validated []string
+	var errs []error
+	var submitted string
+	p.OnKey(func(key *core.Key) {
+		keys = append(keys, key)
+		if key.Name == core.SpaceKey {
+			p.Value = "bar"
+		}
+	})
+	p.OnValidate(func(value string) { validated = append(validated, value) })
+	p.OnError(func(err error) { errs = append(errs, err) })
+	p.OnSubmit(func(value string) { submitted = value })
+
+	enter := &core.Key{Name: core.EnterKey}
+	p.PressKey(enter)
+	assert.Equal(t, []error{err}, errs)
+	assert.Equal(t, core.ErrorState, p.State)
+
+	p.PressKey(&core.Key{Name: core.SpaceKey})
+	p.PressKey(enter)
+	assert.Equal(t, []*core.Key{enter, {Name: core.SpaceKey}, enter}, keys)
+	assert.Equal(t, []string{"foo", "bar"}, validated)
+	assert.Equal(t, []error{err}, errs)
+	assert.Equal(t, "bar", submitted)
+}
+
+func TestTypedListenersWithNilValue(t *testing.T) {
+	p := core.NewPrompt(core.PromptParams[any]{
+		Validate: func(value any) error { return nil },
+		Render:   func(p *core.Prompt[any]) string { return "" },
+	})
+
+	validated, submitted := false, false
+	p.OnValidate(func(value any) { validated = value == nil })
+	p.OnSubmit(func(value any) { submitted = value == nil })
+	p.PressKey(&core.Key{Name: core.EnterKey})
+
+	assert.True(t, validated)
+	assert.True(t, submitted)
+}
+
+func TestTypedListenersWithoutPayload(t *testing.T) {
+	p := newPrompt()
+
+	var key *core.Key
+	var mouse *core.Mouse
+	text, err := "foo", errors.New("foo")
+	p.OnKey(func(k *core.Key) { key = k })
+	p.OnMouse(func(m *core.Mouse) { mouse = m })
+	p.OnPaste(func(t string) { text = t })
+	p.OnError(func(e error) { err = e })
+
+	assert.NotPanics(t, func() {
+		p.Emit(core.KeyEvent)
+		p.Emit(core.MouseEvent)
+		p.Emit(core.PasteEvent)
+		p.Emit(core.ErrorEvent)
+	})
+	assert.Nil(t, key)
+	assert.Nil(t, mouse)
+	assert.Equal(t, "", text)
+	assert.Nil(t, err)
+}
+
+func TestConcurrentEmit(t *testing.T) {
+	p := newPrompt()
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range 100 {
+				unsubscribe := p.On(core.KeyEvent, func(args ...any) {})
+				p.Emit(core.KeyEvent)
+				unsubscribe()
+			}
+		}()
+	}
+	wg.Wait()
+}
+
 func TestParseKey(t *testing.T) {
 	p := newPrompt()
 
@@ -110,6 +250,186 @@ func TestParseKey(t *testing.T) {
 	assert.Equal(t, core.Key{Name: core.TabKey}, *p.ParseKey('\t'))
 	assert.Equal(t, core.Key{Name: core.CancelKey}, *p.ParseKey(3))
 	assert.Equal(t, core.Key{Name: "a", Char: "a"}, *p.ParseKey('a'))
+	assert.Equal(t, core.Key{Name: "a", Ctrl: true}, *p.ParseKey(1))
+	assert.Equal(t, core.Key{Name: "w", Ctrl: true}, *p.ParseKey(23))
+	assert.Equal(t, core.Key{Name: core.EscapeKey}, *p.ParseKey(27))
+}
+
+func parseSequence(t *testing.T, sequence string) core.Key {
+	var keys []core.Key
+	p := core.NewPrompt(core.PromptParams[string]{
+		Input:  strings.NewReader(sequence + "\r"),
+		Output: &bytes.Buffer{},
+		Render: func(p *core.Prompt[string]) string { return "" },
+	})
+	p.On(core.KeyEvent, func(args ...any) {
+		keys = append(keys, *args[0].(*core.Key))
+	})
+	_, err := p.Run()
+	assert.NoError(t, err)
+	assert.NotEmpty(t, keys)
+	return keys[0]
+}
+
+func TestParseKeySequences(t *testing.T) {
+	testCases := []struct {
+		sequence string
+		expected core.Key
+	}{
+		{"\x1b[A", core.Key{Name: core.UpKey}},
+		{"\x1b[B", core.Key{Name: core.DownKey}},
+		{"\x1b[C", core.Key{Name: core.RightKey}},
+		{"\x1b[D", core.Key{Name: core.LeftKey}},
+		{"\x1b[H", core.Key{Name: core.HomeKey}},
+		{"\x1b[F", core.Key{Name: core.EndKey}},
+		{"\x1b[1~", core.Key{Name: core.HomeKey}},
+		{"\x1b[4~", core.Key{Name: core.EndKey}},
+		{"\x1b[Z", core.Key{Name: core.TabKey, Shift: true}},
+		{"\x1b[2~", core.Key{Name: core.InsertKey}},
+		{"\x1b[3~", core.Key{Name: core.DeleteKey}},
+		{"\x1b[5~", core.Key{Name: core.PageUpKey}},
+		{"\x1b[6~", core.Key{Name: core.PageDownKey}},
+		{"\x1bOP", core.Key{Name: core.F1Key}},
+		{"\x1bOS", core.Key{Name: core.F4Key}},
+		{"\x1b[15~", core.Key{Name: core.F5Key}},
+		{"\x1b[24~", core.Key{Name: core.F12Key}},
+		{"\x1b[[A", core.Key{Name: core.F1Key}},
+		{"\x1bOA", core.Key{Name: core.UpKey}},
+		{"\x1bOH", core.Key{Name: core.HomeKey}},
+		{"\x1b[1;5C", core.Key{Name: core.RightKey, Ctrl: true}},
+		{"\x1b[1;2A", core.Key{Name: core.UpKey, Shift: true}},
+		{"\x1b[1;3D", core.Key{Name: core.LeftKey, Alt: true}},
+		{"\x1b[3;5~", core.Key{Name: core.DeleteKey, Ctrl: true}},
+		{"\x1b[1;8H", core.Key{Name: core.HomeKey, Shift: true, Alt: true, Ctrl: true}},
+		{"\x1bO5P", core.Key{Name: core.F1Key, Ctrl: true}},
+		{"\x1bb", core.Key{Name: "b", Alt: true}},
+		{"\x1bB", core.Key{Name: "B", Alt: true}},
+		{"\x1b\x7f", core.Key{Name: core.BackspaceKey, Alt: true}},
+		{"\x1b[99X", core.Key{}},
+		{"\x1b[13;5u", core.Key{Name: core.EnterKey, Ctrl: true}},
+		{"\x1b[57414u", core.Key{Name: core.EnterKey}},
+		{"\x1b[105;5u", core.Key{Name: "i", Ctrl: true}},
+		{"\x1b[9;2u", core.Key{Name: core.TabKey, Shift: true}},
+		{"\x1b[127;5u", core.Key{Name: core.BackspaceKey, Ctrl: true}},
+		{"\x1b[97;3u", core.Key{Name: "a", Alt: true}},
+		{"\x1b[97;6u", core.Key{Name: "a", Shift: true, Ctrl: true}},
+		{"\x1b[97;69u", core.Key{Name: "a", Ctrl: true}},
+		{"\x1b[97:65;7u", core.Key{Name: "a", Alt: true, Ctrl: true}},
+		{"\x1b[97;5:3u", core.Key{}},
+		{"\x1b[57399u", core.Key{}},
+		{"\x1b[200~foo\nbar\x1b[201~", core.Key{Name: core.PasteKey, Char: "foo\nbar"}},
+		{"\x1b[200~\x1b[A\x1b[201~", core.Key{Name: core.PasteKey, Char: "\x1b[A"}},
+	}
+	for _, tC := range testCases {
+		t.Run(fmt.Sprintf("%q", tC.sequence), func(t *testing.T) {
+			assert.Equal(t, tC.expected, parseSequence(t, tC.sequence))
+		})
+	}
+}
+
+func TestParseKeySplitSequence(t *testing.T) {
+	input, writer := io.Pipe()
+	go func() {
+		for _, chunk := range []string{"\x1b", "[", "1;5", "C\r"} {
+			writer.Write([]byte(chunk))
+			time.Sleep(10 * time.Millisecond)
+		}
+	}()
+
+	var keys []core.Key
+	p := core.NewPrompt(core.PromptParams[string]{
+		Input:  input,
+		Output: &bytes.Buffer{},
+		Render: func(p *core.Prompt[string]) string { return "" },
+	})
+	p.On(core.KeyEvent, func(args ...any) {
+		keys = append(keys, *args[0].(*core.Key))
+	})
+	_, err := p.Run()
+	assert.NoError(t, err)
+	assert.Equal(t, []core.Key{{Name: core.RightKey, Ctrl: true}, {Name: core.EnterKey}}, keys)
+}
+
+func TestParseKittyCancelKey(t *testing.T) {
+	p := core.NewPrompt(core.PromptParams[string]{
+		Input:  strings.NewReader("\x1b[99;5u"),
+		Output: &bytes.Buffer{},
+		Render: func(p *core.Prompt[string]) string { return "" },
+	})
+	_, err := p.Run()
+	assert.ErrorIs(t, err, core.ErrCancelPrompt)
+}
+
+func TestIgnoreKittyKeyboardReport(t *testing.T) {
+	var keys []core.Key
+	p := core.NewPrompt(core.PromptParams[string]{
+		Input:  strings.NewReader("\x1b[?1u\r"),
+		Output: &bytes.Buffer{},
+		Render: func(p *core.Prompt[string]) string { return "" },
+	})
+	p.On(core.KeyEvent, func(args ...any) {
+		keys = append(keys, *args[0].(*core.Key))
+	})
+	_, err := p.Run()
+	assert.NoError(t, err)
+	assert.Equal(t, []core.Key{{Name: core.EnterKey}}, keys)
+}
+
+func TestParseMouseSequences(t *testing.T) {
+	testCases := []struct {
+		sequence string
+		expected core.Mouse
+	}{
+		{"\x1b[<0;3;7M", core.Mouse{Button: core.LeftButton, Column: 3, Row: 7}},
+		{"\x1b[<0;3;7m", core.Mouse{Button: core.LeftButton, Column: 3, Row: 7, Release: true}},
+		{"\x1b[<2;1;1M", core.Mouse{Button: core.RightButton, Column: 1, Row: 1}},
+		{"\x1b[<64;10;20M", core.Mouse{Button: core.WheelUpButton, Column: 10, Row: 20}},
+		{"\x1b[<65;10;20M", core.Mouse{Button: core.WheelDownButton, Column: 10, Row: 20}},
+		{"\x1b[<16;1;1M", core.Mouse{Button: core.LeftButton, Column: 1, Row: 1, Ctrl: true}},
+		{"\x1b[<35;4;4M", core.Mouse{Button: core.NoButton, Column: 4, Row: 4, Motion: true}},
+	}
+	for _, tC := range testCases {
+		t.Run(fmt.Sprintf("%q", tC.sequence), func(t *testing.T) {
+			var events []core.Mouse
+			p := core.NewPrompt(core.PromptParams[string]{
+				Input:  strings.NewReader(tC.sequence + "\r"),
+				Output: &bytes.Buffer{},
+				Render: func(p *core.Prompt[string]) string { return "" },
+			})
+			p.On(core.MouseEvent, func(args ...any) {
+				events = append(events, *args[0].(*core.Mouse))
+			})
+			_, err := p.Run()
+			assert.NoError(t, err)
+			assert.Equal(t, []core.Mouse{tC.expected}, events)
+		})
+	}
+}
+
+func TestKeyBinding(t *testing.T) {
+	assert.Equal(t, core.KeyName("a"), (&core.Key{Name: "a", Char: "a"}).Binding())
+	assert.Equal(t, core.KeyName("Shift+Tab"), (&core.Key{Name: core.TabKey, Shift: true}).Binding())
+	assert.Equal(t, core.KeyName("Ctrl+Right"), (&core.Key{Name: core.RightKey, Ctrl: true}).Binding())
+	assert.Equal(t, core.KeyName("Ctrl+Alt+Shift+Up"), (&core.Key{Name: core.UpKey, Shift: true, Ctrl: true, Alt: true}).Binding())
+}
+
+func TestPressKeyWithModifiedBinding(t *testing.T) {
+	core.UpdateSettings(core.SettingsOptions{
+		Aliases: map[core.KeyName]core.Action{
+			"Ctrl+x": core.CancelAction,
+		},
+	})
+	defer delete(core.Settings.Aliases, "Ctrl+x")
+
+	p := newPrompt()
+	p.PressKey(&core.Key{Name: "x", Char: "x"})
+	assert.Equal(t, core.ActiveState, p.State)
+	p.PressKey(&core.Key{Name: "x", Ctrl: true})
+	assert.Equal(t, core.CancelState, p.State)
+
+	p = newPrompt()
+	p.PressKey(&core.Key{Name: core.EnterKey, Alt: true})
+	assert.Equal(t, core.SubmitState, p.State)
 }
 
 func TestTrackValue(t *testing.T) {
@@ -131,6 +451,175 @@ func TestTrackValue(t *testing.T) {
 	assert.Equal(t, 2, p.CursorIndex)
 }
 
+func TestTrackGraphemes(t *testing.T) {
+	p := newPrompt()
+
+	p.Value, p.CursorIndex = p.TrackKeyValue(&core.Key{Name: "ç", Char: "ç"}, "", 0)
+	assert.Equal(t, "ç", p.Value)
+	assert.Equal(t, 1, p.CursorIndex)
+
+	p.Value, p.CursorIndex = p.TrackKeyValue(&core.Key{Name: "日", Char: "日"}, p.Value, p.CursorIndex)
+	p.Value, p.CursorIndex = p.TrackKeyValue(&core.Key{Name: "本", Char: "本"}, p.Value, p.CursorIndex)
+	assert.Equal(t, "ç日本", p.Value)
+	assert.Equal(t, 3, p.CursorIndex)
+
+	p.Value, p.CursorIndex = p.TrackKeyValue(&core.Key{Name: core.LeftKey}, p.Value, p.CursorIndex)
+	p.Value, p.CursorIndex = p.TrackKeyValue(&core.Key{Name: "👍", Char: "👍"}, p.Value, p.CursorIndex)
+	assert.Equal(t, "ç日👍本", p.Value)
+	assert.Equal(t, 3, p.CursorIndex)
+
+	// A skin tone modifier merges into the emoji before the cursor
+	p.Value, p.CursorIndex = p.TrackKeyValue(&core.Key{Name: "🏽", Char: "🏽"}, p.Value, p.CursorIndex)
+	assert.Equal(t, "ç日👍🏽本", p.Value)
+	assert.Equal(t, 3, p.CursorIndex)
+
+	p.Value, p.CursorIndex = p.TrackKeyValue(&core.Key{Name: core.BackspaceKey}, p.Value, p.CursorIndex)
+	assert.Equal(t, "ç日本", p.Value)
+	assert.Equal(t, 2, p.CursorIndex)
+
+	p.Value, p.CursorIndex = p.TrackKeyValue(&core.Key{Name: core.EndKey}, p.Value, p.CursorIndex)
+	assert.Equal(t, 3, p.CursorIndex)
+
+	p.Value, p.CursorIndex = p.TrackKeyValue(&core.Key{Name: core.BackspaceKey}, p.Value, p.CursorIndex)
+	assert.Equal(t, "ç日", p.Value)
+	assert.Equal(t, 2, p.CursorIndex)
+}
+
+func TestTrackEditingKeys(t *testing.T) {
+	p := newPrompt()
+	track := func(key *core.Key) {
+		p.Value, p.CursorIndex = p.TrackKeyValue(key, p.Value, p.CursorIndex)
+	}
+	p.Value, p.CursorIndex = "foo bar-baz", 11
+
+	track(&core.Key{Name: "a", Ctrl: true})
+	assert.Equal(t, 0, p.CursorIndex)
+	track(&core.Key{Name: "e", Ctrl: true})
+	assert.Equal(t, 11, p.CursorIndex)
+
+	track(&core.Key{Name: "b", Alt: true})
+	assert.Equal(t, 8, p.CursorIndex)
+	track(&core.Key{Name: "b", Alt: true})
+	assert.Equal(t, 4, p.CursorIndex)
+	track(&core.Key{Name: "f", Alt: true})
+	assert.Equal(t, 7, p.CursorIndex)
+
+	track(&core.Key{Name: core.DeleteKey})
+	assert.Equal(t, "foo barbaz", p.Value)
+	assert.Equal(t, 7, p.CursorIndex)
+
+	track(&core.Key{Name: "k", Ctrl: true})
+	assert.Equal(t, "foo bar", p.Value)
+	track(&core.Key{Name: "w", Ctrl: true})
+	assert.Equal(t, "foo ", p.Value)
+	assert.Equal(t, 4, p.CursorIndex)
+
+	// Consecutive kills are yanked back at once
+	track(&core.Key{Name: "y", Ctrl: true})
+	assert.Equal(t, "foo barbaz", p.Value)
+	assert.Equal(t, 10, p.CursorIndex)
+
+	track(&core.Key{Name: "u", Ctrl: true})
+	assert.Equal(t, "", p.Value)
+	assert.Equal(t, 0, p.CursorIndex)
+	track(&core.Key{Name: "x", Char: "x"})
+	track(&core.Key{Name: "y", Ctrl: true})
+	assert.Equal(t, "xfoo barbaz", p.Value)
+	assert.Equal(t, 11, p.CursorIndex)
+}
+
+func TestTrackEditingKeysGraphemes(t *testing.T) {
+	p := newPrompt()
+
+	p.Value, p.CursorIndex = p.TrackKeyValue(&core.Key{Name: "w", Ctrl: true}, "日本 👍🏽", 4)
+	assert.Equal(t, "日本 ", p.Value)
+	assert.Equal(t, 3, p.CursorIndex)
+
+	p.Value, p.CursorIndex = p.TrackKeyValue(&core.Key{Name: "b", Alt: true}, "日本 e\u0301te\u0301", 6)
+	assert.Equal(t, 3, p.CursorIndex)
+}
+
+func TestTrackUndoRedo(t *testing.T) {
+	p := newPrompt()
+	track := func(key *core.Key) {
+		p.Value, p.CursorIndex = p.TrackKeyValue(key, p.Value, p.CursorIndex)
+	}
+	undo := &core.Key{Name: "z", Ctrl: true}
+	redo := &core.Key{Name: "z", Ctrl: true, Shift: true}
+
+	for _, char := range "foo" {
+		track(&core.Key{Name: core.KeyName(char), Char: string(char)})
+	}
+	track(&core.Key{Name: core.SpaceKey})
+	for _, char := range "bar" {
+		track(&core.Key{Name: core.KeyName(char), Char: string(char)})
+	}
+	assert.Equal(t, "foo bar", p.Value)
+
+	// Typing is coalesced by words
+	track(undo)
+	assert.Equal(t, "foo ", p.Value)
+	assert.Equal(t, 4, p.CursorIndex)
+	track(undo)
+	assert.Equal(t, "", p.Value)
+	assert.Equal(t, 0, p.CursorIndex)
+	track(undo)
+	assert.Equal(t, "", p.Value)
+
+	track(redo)
+	assert.Equal(t, "foo ", p.Value)
+	// Alt+Z redoes in terminals not reporting Ctrl+Shift+Z
+	track(&core.Key{Name: "z", Alt: true})
+	assert.Equal(t, "foo bar", p.Value)
+	assert.Equal(t, 7, p.CursorIndex)
+	track(redo)
+	assert.Equal(t, "foo bar", p.Value)
+
+	// Cursor moves split the typing, and new edits clear the redo stack
+	track(&core.Key{Name: core.HomeKey})
+	track(&core.Key{Name: "x", Char: "x"})
+	track(&core.Key{Name: core.EndKey})
+	track(&core.Key{Name: "y", Char: "y"})
+	assert.Equal(t, "xfoo bary", p.Value)
+	track(undo)
+	assert.Equal(t, "xfoo bar", p.Value)
+	track(&core.Key{Name: core.BackspaceKey})
+	track(redo)
+	assert.Equal(t, "xfoo ba", p.Value)
+	track(undo)
+	track(undo)
+	assert.Equal(t, "foo bar", p.Value)
+
+	// Changes made outside of TrackKeyValue are undone as an edit
+	p.Value = "baz"
+	track(undo)
+	assert.Equal(t, "foo bar", p.Value)
+}
+
+func TestTrackPaste(t *testing.T) {
+	p := newPrompt()
+
+	p.Value, p.CursorIndex = p.TrackKeyValue(&core.Key{Name: core.PasteKey, Char: "foo"}, "ab", 1)
+	assert.Equal(t, "afoob", p.Value)
+	assert.Equal(t, 4, p.CursorIndex)
+
+	p.Value, p.CursorIndex = p.TrackKeyValue(&core.Key{Name: core.PasteKey, Char: "x\r\ny\tz\x07"}, "", 0)
+	assert.Equal(t, "x y z", p.Value)
+	assert.Equal(t, 5, p.CursorIndex)
+}
+
+func TestPasteEvent(t *testing.T) {
+	p := newPrompt()
+	var pasted string
+	p.On(core.PasteEvent, func(args ...any) {
+		pasted = args[0].(string)
+	})
+
+	p.PressKey(&core.Key{Name: core.PasteKey, Char: "foo\nbar"})
+	assert.Equal(t, "foo\nbar", pasted)
+	assert.Equal(t, core.ActiveState, p.State)
+}
+
 func TestTrackCursor(t *testing.T) {
 	p := newPrompt()
 
@@ -224,27 +713,196 @@ func TestEmitFinalizeBeforeSubmit(t *testing.T) {
 	assert.Equal(t, 2, calledTimes)
 }
 
-func TestAsyncValidation(t *testing.T) {
-	p := newPrompt()
-	p.Validate = func(value string) error {
-		time.Sleep(530 * time.Millisecond)
-		return nil
+type validationFrame struct {
+	state        core.State
+	isValidating bool
+	duration     time.Duration
+	err          string
+}
+
+// newValidationPrompt runs a prompt typing the input, and records the state of each rendered frame.
+func newValidationPrompt(params core.PromptParams[string]) (*core.Prompt[string], chan validationFrame) {
+	frames := make(chan validationFrame, 1000)
+	params.Output = &bytes.Buffer{}
+	params.Render = func(p *core.Prompt[string]) string {
+		frames <- validationFrame{p.State, p.IsValidating, p.ValidationDuration, p.Error}
+		return fmt.Sprintf("%d %s %v", p.State, p.Value, p.ValidationDuration)
 	}
+	p := core.NewPrompt(params)
+	p.On(core.KeyEvent, func(args ...any) {
+		p.Value, p.CursorIndex = p.TrackKeyValue(args[0].(*core.Key), p.Value, p.CursorIndex)
+	})
+	return p, frames
+}
 
-	go p.PressKey(&core.Key{Name: core.EnterKey})
-	time.Sleep(400 * time.Millisecond)
-	assert.Equal(t, true, p.IsValidating)
-	assert.Equal(t, core.ValidateState, p.State)
-	assert.GreaterOrEqual(t, p.ValidationDuration, 400*time.Millisecond)
+// waitFrame waits for a frame matching the condition.
+func waitFrame(t *testing.T, frames chan validationFrame, condition func(frame validationFrame) bool) validationFrame {
+	t.Helper()
+	timeout := time.After(2 * time.Second)
+	for {
+		select {
+		case frame := <-frames:
+			if condition(frame) {
+				return frame
+			}
+		case <-timeout:
+			t.Fatal("frame not rendered")
+			return validationFrame{}
+		}
+	}
+}
 
-	time.Sleep(126 * time.Millisecond)
-	assert.Equal(t, true, p.IsValidating)
-	assert.Equal(t, core.ValidateState, p.State)
-	assert.GreaterOrEqual(t, p.ValidationDuration, 525*time.Millisecond)
+func TestAsyncValidation(t *testing.T) {
+	p, frames := newValidationPrompt(core.PromptParams[string]{
+		Input: strings.NewReader("\r"),
+		Validate: func(value string) error {
+			time.Sleep(600 * time.Millisecond)
+			return nil
+		},
+	})
+
+	_, err := p.Run()
+	assert.NoError(t, err)
+
+	frame := waitFrame(t, frames, func(frame validationFrame) bool { return frame.isValidating })
+	assert.Equal(t, core.ValidateState, frame.state)
+	assert.GreaterOrEqual(t, frame.duration, 400*time.Millisecond)
+
+	frame = waitFrame(t, frames, func(frame validationFrame) bool { return frame.isValidating })
+	assert.Equal(t, core.ValidateState, frame.state)
+	assert.GreaterOrEqual(t, frame.duration, 525*time.Millisecond)
+
+	frame = waitFrame(t, frames, func(frame validationFrame) bool { return !frame.isValidating })
+	assert.Equal(t, core.SubmitState, frame.state)
+}
+
+func TestCancelDuringValidation(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	started, release := make(chan struct{}), make(chan struct{})
+	defer close(release)
+	p, _ := newValidationPrompt(core.PromptParams[string]{
+		Context: ctx,
+		Input:   strings.NewReader("\r"),
+		Validate: func(value string) error {
+			close(started)
+			<-release
+			return nil
+		},
+	})
 
-	time.Sleep(10 * time.Millisecond)
+	go func() {
+		<-started
+		cancel()
+	}()
+
+	_, err := p.Run()
+	assert.ErrorIs(t, err, core.ErrCancelPrompt)
 	assert.Equal(t, false, p.IsValidating)
-	assert.Equal(t, core.SubmitState, p.State)
+}
+
+func TestCancelKeyDuringValidation(t *testing.T) {
+	input, writer := io.Pipe()
+	defer writer.Close()
+	started, release := make(chan struct{}), make(chan struct{})
+	defer close(release)
+	p, _ := newValidationPrompt(core.PromptParams[string]{
+		Input: input,
+		Validate: func(value string) error {
+			close(started)
+			<-release
+			return nil
+		},
+	})
+
+	go func() {
+		writer.Write([]byte("a\r"))
+		<-started
+		// Keys typed while validating are dropped, except to cancel the prompt
+		writer.Write([]byte("xyz\x03"))
+	}()
+
+	value, err := p.Run()
+	assert.ErrorIs(t, err, core.ErrCancelPrompt)
+	assert.Equal(t, "a", value)
+	assert.Equal(t, false, p.IsValidating)
+}
+
+func TestPressKeyWhileRunning(t *testing.T) {
+	input, writer := io.Pipe()
+	defer writer.Close()
+	p, frames := newValidationPrompt(core.PromptParams[string]{
+		Input: input,
+		Validate: func(value string) error {
+			time.Sleep(10 * time.Millisecond)
+			return fmt.Errorf("invalid value: %v", value)
+		},
+	})
+
+	result := make(chan string)
+	go func() {
+		value, _ := p.Run()
+		result <- value
+	}()
+	waitFrame(t, frames, func(frame validationFrame) bool { return frame.state == core.InitialState })
+
+	// Keys pressed while the prompt runs are handled by its event loop, including the validation
+	p.PressKey(&core.Key{Name: "f", Char: "f"})
+	p.PressKey(&core.Key{Name: core.EnterKey})
+	assert.Equal(t, core.ErrorState, p.State)
+	assert.Equal(t, "invalid value: f", p.Error)
+
+	p.PressKey(&core.Key{Name: core.CancelKey})
+	assert.Equal(t, "f", <-result)
+}
+
+func TestLiveValidation(t *testing.T) {
+	cancelled := make(chan string, 10)
+	validated := make(chan string, 10)
+	input, writer := io.Pipe()
+	defer writer.Close()
+	p, frames := newValidationPrompt(core.PromptParams[string]{
+		Input: input,
+		LiveValidate: func(ctx context.Context, value string) error {
+			if value == "fo" {
+				<-ctx.Done()
+				cancelled <- value
+				return ctx.Err()
+			}
+			defer func() { validated <- value }()
+			if value != "foo" {
+				return fmt.Errorf("invalid value: %v", value)
+			}
+			return nil
+		},
+		LiveValidateDelay: 10 * time.Millisecond,
+	})
+
+	result := make(chan string)
+	go func() {
+		value, _ := p.Run()
+		result <- value
+	}()
+
+	writer.Write([]byte("f"))
+	assert.Equal(t, "f", <-validated)
+	frame := waitFrame(t, frames, func(frame validationFrame) bool { return frame.state == core.ErrorState })
+	assert.Equal(t, "invalid value: f", frame.err)
+
+	// The validation of a previous value is cancelled once it changes
+	writer.Write([]byte("o"))
+	time.Sleep(20 * time.Millisecond)
+	writer.Write([]byte("o"))
+	assert.Equal(t, "fo", <-cancelled)
+	assert.Equal(t, "foo", <-validated)
+
+	// Typing does not wait for the debounced validation
+	writer.Write([]byte("x\x7f"))
+	assert.Empty(t, validated)
+	assert.Equal(t, "foo", <-validated)
+
+	writer.Write([]byte("\r"))
+	assert.Equal(t, "foo", <-result)
+	assert.Equal(t, "foo", <-validated)
 }
 
 func TestDiffLines(t *testing.T) {
@@ -256,6 +914,61 @@ func TestDiffLines(t *testing.T) {
 	assert.Equal(t, []int(nil), p.DiffLines("a\nb\nc", "a\nb\nc"))
 }
 
+func TestRenderWrappedLines(t *testing.T) {
+	output := &MockTerminal{Width: 10, Height: 40}
+	p := core.NewPrompt(core.PromptParams[string]{
+		Output: output,
+		Render: func(p *core.Prompt[string]) string {
+			return "0123456789abcde\r\nfoo\r\n" + p.Value
+		},
+	})
+
+	p.PressKey(&core.Key{Name: "a", Char: "a"})
+	output.Reset()
+	p.Value = "bar"
+	p.PressKey(&core.Key{Name: "b", Char: "b"})
+
+	// The first line takes two rows, so the frame takes four rows and the diff starts on the fourth row
+	expected := sisteransi.MoveCursor(-3, -999) + sisteransi.MoveCursor(3, 0) + sisteransi.EraseDown() + "bar"
+	assert.Equal(t, expected, output.String())
+}
+
+func TestSize(t *testing.T) {
+	p := core.NewPrompt(core.PromptParams[string]{
+		Output: &MockTerminal{Width: 120, Height: 40},
+		Render: func(p *core.Prompt[string]) string { return "" },
+	})
+
+	width, height, err := p.Size()
+	assert.NoError(t, err)
+	assert.Equal(t, 120, width)
+	assert.Equal(t, 40, height)
+}
+
+func TestSizeWithoutTerminal(t *testing.T) {
+	p := core.NewPrompt(core.PromptParams[string]{
+		Output: &bytes.Buffer{},
+		Render: func(p *core.Prompt[string]) string { return "" },
+	})
+
+	_, _, err := p.Size()
+	assert.ErrorIs(t, err, core.ErrNoTerminal)
+}
+
+func TestRunWithReaderAndWriter(t *testing.T) {
+	output := &bytes.Buffer{}
+	p := core.NewTextPrompt(core.TextPromptParams{
+		Input:  strings.NewReader("foo\r"),
+		Output: output,
+		Render: func(p *core.TextPrompt) string { return p.Value },
+	})
+
+	value, err := p.Run()
+	assert.NoError(t, err)
+	assert.Equal(t, "foo", value)
+	assert.Contains(t, output.String(), "foo")
+}
+
 func TestLimitLines(t *testing.T) {
 	testCases := []struct {
 		description string
@@ -518,6 +1231,17 @@ func TestFormatLines(t *testing.T) {
 				fmt.Sprintf("* %s *", strings.Repeat("c", 3)),
 			}, "\r\n"),
 		},
+		{
+			description: "format overflowed wide lines",
+			lines:       []string{strings.Repeat("日", 45)},
+			options: core.FormatLinesOptions{
+				Default: core.FormatLineOptions{Sides: "|"},
+			},
+			expected: strings.Join([]string{
+				fmt.Sprintf("| %s |", strings.Repeat("日", 38)),
+				fmt.Sprintf("| %s |", strings.Repeat("日", 7)),
+			}, "\r\n"),
+		},
 		{
 			description: "format double overflowed lines",
 			lines:       []string{strings.Repeat("a", 180), strings.Repeat("b", 180)},
@@ -606,3 +1330,388 @@ func TestFormatLines(t *testing.T) {
 		assert.Equal(t, tC.expected, frame)
 	}
 }
+
+func TestRunLineMode(t *testing.T) {
+	output := &bytes.Buffer{}
+	p := core.NewTextPrompt(core.TextPromptParams{
+		Input:  strings.NewReader("bar\nfoo\n"),
+		Output: output,
+		Validate: func(value string) error {
+			if value != "foo" {
+				return fmt.Errorf("invalid value: %s", value)
+			}
+			return nil
+		},
+		Render: func(p *core.TextPrompt) string { return p.Error + "?" },
+	})
+	p.LineMode = true
+
+	value, err := p.Run()
+	assert.NoError(t, err)
+	assert.Equal(t, "foo", value)
+	assert.Equal(t, core.SubmitState, p.State)
+	assert.Equal(t, "?\ninvalid value: bar?\n", output.String())
+}
+
+func TestRunLineModeWithoutTerminal(t *testing.T) {
+	output := &bytes.Buffer{}
+	p := core.NewTextPrompt(core.TextPromptParams{
+		Input:  MockPipe{strings.NewReader("foo\n")},
+		Output: output,
+		Render: func(p *core.TextPrompt) string { return "?" },
+	})
+
+	value, err := p.Run()
+	assert.NoError(t, err)
+	assert.Equal(t, "foo", value)
+	assert.True(t, p.LineMode)
+	assert.Equal(t, "?\n", output.String())
+}
+
+func TestRunLineModeParam(t *testing.T) {
+	output := &bytes.Buffer{}
+	p := core.NewTextPrompt(core.TextPromptParams{
+		Input:    strings.NewReader("foo\n"),
+		Output:   output,
+		LineMode: true,
+		Render:   func(p *core.TextPrompt) string { return "?" },
+	})
+
+	value, err := p.Run()
+	assert.NoError(t, err)
+	assert.Equal(t, "foo", value)
+	assert.Equal(t, "?\n", output.String())
+}
+
+func TestRunLineModeWithSharedInput(t *testing.T) {
+	input := core.NewSharedInput(strings.NewReader("my-app\nyes\nfoo, bar\n"))
+
+	text := core.NewTextPrompt(core.TextPromptParams{
+		Input:  input,
+		Output: &bytes.Buffer{},
+		Render: func(p *core.TextPrompt) string { return "" },
+	})
+	text.LineMode = true
+	name, err := text.Run()
+	assert.NoError(t, err)
+	assert.Equal(t, "my-app", name)
+
+	confirm := core.NewConfirmPrompt(core.ConfirmPromptParams{
+		Input:  input,
+		Output: &bytes.Buffer{},
+		Render: func(p *core.ConfirmPrompt) string { return "" },
+	})
+	confirm.LineMode = true
+	confirmed, err := confirm.Run()
+	assert.NoError(t, err)
+	assert.True(t, confirmed)
+
+	multiSelect := core.NewMultiSelectPrompt(core.MultiSelectPromptParams[string]{
+		Input:  input,
+		Output: &bytes.Buffer{},
+		Options: []*core.MultiSelectOption[string]{
+			{Label: "foo", Value: "foo"},
+			{Label: "bar", Value: "bar"},
+			{Label: "baz", Value: "baz"},
+		},
+		Render: func(p *core.MultiSelectPrompt[string]) string { return "" },
+	})
+	multiSelect.LineMode = true
+	values, err := multiSelect.Run()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"foo", "bar"}, values)
+}
+
+func TestRunWithTypedAheadInput(t *testing.T) {
+	input := core.NewSharedInput(strings.NewReader("foo\rbar\r"))
+	run := func() string {
+		p := core.NewTextPrompt(core.TextPromptParams{
+			Input:  input,
+			Output: &bytes.Buffer{},
+			Render: func(p *core.TextPrompt) string { return "" },
+		})
+		value, err := p.Run()
+		assert.NoError(t, err)
+		return value
+	}
+
+	assert.Equal(t, "foo", run())
+	assert.Equal(t, "bar", run())
+}
+
+func TestSharedInputRead(t *testing.T) {
+	input := core.NewSharedInput(strings.NewReader("foo\rbar"))
+	p := core.NewTextPrompt(core.TextPromptParams{
+		Input:  input,
+		Output: &bytes.Buffer{},
+		Render: func(p *core.TextPrompt) string { return "" },
+	})
+	_, err := p.Run()
+	assert.NoError(t, err)
+
+	rest, err := io.ReadAll(input)
+	assert.NoError(t, err)
+	assert.Equal(t, "bar", string(rest))
+}
+
+func TestRunLeavesPendingInputToNextPrompt(t *testing.T) {
+	reader, writer := io.Pipe()
+	defer writer.Close()
+	input := core.NewSharedInput(reader)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	first := core.NewTextPrompt(core.TextPromptParams{
+		Context: ctx,
+		Input:   input,
+		Output:  &bytes.Buffer{},
+		Render:  func(p *core.TextPrompt) string { return "" },
+	})
+	time.AfterFunc(10*time.Millisecond, cancel)
+	_, err := first.Run()
+	assert.ErrorIs(t, err, core.ErrCancelPrompt)
+
+	go writer.Write([]byte("foo\r"))
+	second := core.NewTextPrompt(core.TextPromptParams{
+		Input:  input,
+		Output: &bytes.Buffer{},
+		Render: func(p *core.TextPrompt) string { return "" },
+	})
+	value, err := second.Run()
+	assert.NoError(t, err)
+	assert.Equal(t, "foo", value)
+}
+
+func TestRunWithUnterminatedPaste(t *testing.T) {
+	input, writer := io.Pipe()
+	defer writer.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	p := core.NewTextPrompt(core.TextPromptParams{
+		Context: ctx,
+		Input:   input,
+		Output:  &bytes.Buffer{},
+		Render:  func(p *core.TextPrompt) string { return "" },
+	})
+	go writer.Write([]byte("\x1b[200~foo"))
+	time.AfterFunc(10*time.Millisecond, cancel)
+
+	done := make(chan error)
+	go func() {
+		_, err := p.Run()
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		assert.ErrorIs(t, err, core.ErrCancelPrompt)
+	case <-time.After(time.Second):
+		t.Fatal("the prompt did not return")
+	}
+}
+
+func TestRunLineModeWithoutInput(t *testing.T) {
+	p := core.NewTextPrompt(core.TextPromptParams{
+		Input:  strings.NewReader(""),
+		Output: &bytes.Buffer{},
+		Render: func(p *core.TextPrompt) string { return "" },
+	})
+	p.LineMode = true
+
+	var cancelled bool
+	p.On(core.CancelEvent, func(args ...any) { cancelled = true })
+
+	_, err := p.Run()
+	assert.ErrorIs(t, err, core.ErrCancelPrompt)
+	assert.Equal(t, core.CancelState, p.State)
+	assert.True(t, cancelled)
+}
+
+func TestRunLineModeWithCancelledContext(t *testing.T) {
+	input, writer := io.Pipe()
+	defer writer.Close()
+	ctx, cancel := context.WithCancel(context.Background())
+	p := core.NewTextPrompt(core.TextPromptParams{
+		Context: ctx,
+		Input:   input,
+		Output:  &bytes.Buffer{},
+		Render:  func(p *core.TextPrompt) string { return "" },
+	})
+	p.LineMode = true
+
+	time.AfterFunc(10*time.Millisecond, cancel)
+	_, err := p.Run()
+	assert.ErrorIs(t, err, core.ErrCancelPrompt)
+	assert.Equal(t, core.CancelState, p.State)
+}
+
+func TestRunLineModeWithLiveValidate(t *testing.T) {
+	output := &bytes.Buffer{}
+	p := core.NewTextPrompt(core.TextPromptParams{
+		Input:  strings.NewReader("bar\nfoo\n"),
+		Output: output,
+		LiveValidate: func(ctx context.Context, value string) error {
+			if value != "foo" {
+				return fmt.Errorf("invalid value: %s", value)
+			}
+			return nil
+		},
+		Render: func(p *core.TextPrompt) string { return p.Error + "?" },
+	})
+	p.LineMode = true
+
+	value, err := p.Run()
+	assert.NoError(t, err)
+	assert.Equal(t, "foo", value)
+	assert.Equal(t, "?\ninvalid value: bar?\n", output.String())
+}
+
+func TestRunLineModeWithUnterminatedLine(t *testing.T) {
+	p := core.NewTextPrompt(core.TextPromptParams{
+		Input:        strings.NewReader("foo"),
+		Output:       &bytes.Buffer{},
+		InitialValue: "bar",
+		Render:       func(p *core.TextPrompt) string { return "" },
+	})
+	p.LineMode = true
+
+	value, err := p.Run()
+	assert.NoError(t, err)
+	assert.Equal(t, "foo", value)
+}
+
+func TestTimeout(t *testing.T) {
+	testCases := []struct {
+		description   string
+		validate      func(value string) error
+		timeoutCancel bool
+		expected      error
+		state         core.State
+	}{
+		{
+			description: "submit the initial value",
+			state:       core.SubmitState,
+		},
+		{
+			description:   "cancel",
+			timeoutCancel: true,
+			expected:      core.ErrTimeout,
+			state:         core.CancelState,
+		},
+		{
+			description: "cancel an invalid value",
+			validate:    func(value string) error { return errors.New("invalid") },
+			expected:    core.ErrTimeout,
+			state:       core.CancelState,
+		},
+	}
+
+	for _, tC := range testCases {
+		for _, lineMode := range []bool{false, true} {
+			t.Run(fmt.Sprintf("%s (line mode: %t)", tC.description, lineMode), func(t *testing.T) {
+				input, writer := io.Pipe()
+				defer writer.Close()
+				p := core.NewPrompt(core.PromptParams[string]{
+					Input:         input,
+					Output:        &bytes.Buffer{},
+					InitialValue:  "foo",
+					Validate:      tC.validate,
+					Timeout:       50 * time.Millisecond,
+					TimeoutCancel: tC.timeoutCancel,
+					Render:        func(p *core.Prompt[string]) string { return p.Value },
+				})
+				p.LineMode = lineMode
+
+				start := time.Now()
+				value, err := p.Run()
+				assert.Equal(t, tC.expected, err)
+				assert.Equal(t, "foo", value)
+				assert.Equal(t, tC.state, p.State)
+				assert.GreaterOrEqual(t, time.Since(start), 50*time.Millisecond)
+			})
+		}
+	}
+}
+
+func TestTimeoutCountdown(t *testing.T) {
+	input, writer := io.Pipe()
+	defer writer.Close()
+	var countdown []time.Duration
+	p := core.NewPrompt(core.PromptParams[string]{
+		Input:   input,
+		Output:  &bytes.Buffer{},
+		Timeout: 1100 * time.Millisecond,
+		Render: func(p *core.Prompt[string]) string {
+			countdown = append(countdown, p.TimeoutRemaining)
+			return p.TimeoutRemaining.String()
+		},
+	})
+
+	_, err := p.Run()
+	assert.NoError(t, err)
+	// The countdown is rendered on whole seconds, and hidden once the prompt is done
+	assert.Len(t, countdown, 3)
+	assert.Equal(t, 1100*time.Millisecond, countdown[0])
+	assert.InDelta(t, time.Second, countdown[1], float64(50*time.Millisecond))
+	assert.Zero(t, countdown[2])
+}
+
+func TestTimeoutStoppedByKey(t *testing.T) {
+	input, writer := io.Pipe()
+	defer writer.Close()
+	p, frames := newValidationPrompt(core.PromptParams[string]{
+		Input:   input,
+		Timeout: 50 * time.Millisecond,
+	})
+
+	result := make(chan string)
+	go func() {
+		value, _ := p.Run()
+		result <- value
+	}()
+
+	waitFrame(t, frames, func(frame validationFrame) bool { return frame.state == core.InitialState })
+	writer.Write([]byte("a"))
+	time.Sleep(100 * time.Millisecond)
+	assert.Empty(t, result)
+	writer.Write([]byte("\r"))
+	assert.Equal(t, "a", <-result)
+}
+
+func TestEphemeral(t *testing.T) {
+	testCases := []struct {
+		description string
+		summary     func(value string) string
+		expected    string
+	}{
+		{
+			description: "erase the frame",
+			expected:    sisteransi.EraseDown() + sisteransi.ShowCursor(),
+		},
+		{
+			description: "collapse the frame to its summary",
+			summary:     func(value string) string { return "picked " + value },
+			expected:    sisteransi.EraseDown() + "picked foo" + sisteransi.ShowCursor() + "\r\n",
+		},
+	}
+
+	for _, tC := range testCases {
+		t.Run(tC.description, func(t *testing.T) {
+			input, writer := io.Pipe()
+			defer writer.Close()
+			output := &bytes.Buffer{}
+			p := core.NewPrompt(core.PromptParams[string]{
+				Input:        input,
+				Output:       output,
+				InitialValue: "foo",
+				Ephemeral:    true,
+				Summary:      tC.summary,
+				Render:       func(p *core.Prompt[string]) string { return "frame\r\n" + p.Value },
+			})
+
+			go writer.Write([]byte("\r"))
+			value, err := p.Run()
+			assert.NoError(t, err)
+			assert.Equal(t, "foo", value)
+			assert.True(t, strings.HasSuffix(output.String(), tC.expected), output.String())
+		})
+	}
+}
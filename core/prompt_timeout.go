@@ -0,0 +1,67 @@
+package core
+
+import "time"
+
+// startTimeout starts the countdown of the Timeout, which is rendered through TimeoutRemaining each second.
+// Once it ends, the current value is submitted, or the prompt is cancelled with ErrTimeout if TimeoutCancel is set.
+func (p *Prompt[TValue]) startTimeout() {
+	if p.Timeout <= 0 {
+		return
+	}
+
+	deadline := time.Now().Add(p.Timeout)
+	var tick func()
+	schedule := func(remaining time.Duration) {
+		// Ticks are aligned on whole seconds, so the rendered seconds change right on time
+		next := remaining - remaining.Truncate(time.Second)
+		if next == 0 {
+			next = time.Second
+		}
+		p.timeoutTimer = time.AfterFunc(next, func() { p.post(tick) })
+	}
+	tick = func() {
+		if p.TimeoutRemaining <= 0 {
+			return
+		}
+		remaining := time.Until(deadline)
+		if remaining <= 0 {
+			p.TimeoutRemaining = 0
+			p.timeOut()
+			return
+		}
+		p.TimeoutRemaining = remaining
+		p.render()
+		schedule(remaining)
+	}
+
+	p.TimeoutRemaining = p.Timeout
+	schedule(p.Timeout)
+}
+
+// stopTimeout stops the countdown of the Timeout, e.g. once the user presses a key.
+func (p *Prompt[TValue]) stopTimeout() {
+	p.TimeoutRemaining = 0
+	if p.timeoutTimer != nil {
+		p.timeoutTimer.Stop()
+	}
+}
+
+// timeOut ends the prompt once its Timeout is over.
+// The current value is validated before being submitted, and the prompt is cancelled with ErrTimeout if it is invalid.
+func (p *Prompt[TValue]) timeOut() {
+	if p.TimeoutCancel {
+		p.timedOut = true
+		p.cancel()
+		return
+	}
+
+	p.validate(func(err error) {
+		if err != nil {
+			p.timedOut = true
+			p.State = CancelState
+		} else {
+			p.State = SubmitState
+		}
+		p.update()
+	})
+}
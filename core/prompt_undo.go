@@ -0,0 +1,85 @@
+package core
+
+// editState is a snapshot of a string value edited by TrackKeyValue, along with its cursor position.
+type editState struct {
+	value       string
+	cursorIndex int
+}
+
+// editHistory holds the undo and redo stacks of the string value edited by TrackKeyValue.
+type editHistory struct {
+	undo []editState
+	redo []editState
+	// last is the state after the last tracked edit, used to detect changes made outside of TrackKeyValue
+	last    editState
+	tracked bool
+	// typing is set while consecutive typed characters are coalesced into a single edit
+	typing      bool
+	typingSpace bool
+}
+
+// syncEdit records a change of the value made outside of TrackKeyValue (e.g. a path completion) as an edit.
+func (p *Prompt[TValue]) syncEdit(value string, cursorIndex int) {
+	h := &p.edits
+	if h.tracked && value != h.last.value {
+		h.undo = append(h.undo, h.last)
+		h.redo = nil
+		h.typing = false
+	}
+	h.last = editState{value, cursorIndex}
+	h.tracked = true
+}
+
+// recordEdit records an edit of the value in the undo stack.
+// Consecutive typed characters are coalesced into a single edit, which is split at word boundaries.
+//
+// Parameters:
+//   - before (editState): The state before the edit.
+//   - after (editState): The state after the edit.
+//   - typed (string): The typed text, or an empty string if the edit was not typing.
+func (p *Prompt[TValue]) recordEdit(before, after editState, typed string) {
+	h := &p.edits
+	h.last = after
+	if before.value == after.value {
+		// Cursor moves end the current typing
+		h.typing = h.typing && before.cursorIndex == after.cursorIndex
+		return
+	}
+
+	space := typed == " "
+	coalesce := typed != "" && h.typing && !(h.typingSpace && !space)
+	if !coalesce {
+		h.undo = append(h.undo, before)
+	}
+	h.redo = nil
+	h.typing = typed != ""
+	h.typingSpace = space
+}
+
+// undoEdit restores the value before the last edit, and pushes the current value to the redo stack.
+func (p *Prompt[TValue]) undoEdit(value string, cursorIndex int) (newValue string, newCursorIndex int) {
+	h := &p.edits
+	h.typing = false
+	if len(h.undo) == 0 {
+		return value, cursorIndex
+	}
+	state := h.undo[len(h.undo)-1]
+	h.undo = h.undo[:len(h.undo)-1]
+	h.redo = append(h.redo, editState{value, cursorIndex})
+	h.last = state
+	return state.value, state.cursorIndex
+}
+
+// redoEdit restores the value undone last, and pushes the current value back to the undo stack.
+func (p *Prompt[TValue]) redoEdit(value string, cursorIndex int) (newValue string, newCursorIndex int) {
+	h := &p.edits
+	h.typing = false
+	if len(h.redo) == 0 {
+		return value, cursorIndex
+	}
+	state := h.redo[len(h.redo)-1]
+	h.redo = h.redo[:len(h.redo)-1]
+	h.undo = append(h.undo, editState{value, cursorIndex})
+	h.last = state
+	return state.value, state.cursorIndex
+}
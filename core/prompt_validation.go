@@ -0,0 +1,137 @@
+package core
+
+import (
+	"context"
+	"reflect"
+	"time"
+)
+
+const (
+	// DefaultLiveValidateDelay is the time waited after the last change of the value before it is validated live.
+	DefaultLiveValidateDelay = 300 * time.Millisecond
+	// validationRenderDelay is the time a submitted value is validated before the validation is rendered.
+	validationRenderDelay = 400 * time.Millisecond
+	// validationRenderInterval is the interval between renders of a validation in progress, e.g. to animate it.
+	validationRenderInterval = 125 * time.Millisecond
+)
+
+// validate validates a submitted value with Validate and then LiveValidate.
+// While the prompt runs, the validation runs in its own goroutine, so the event loop keeps rendering the ValidateState,
+// and its result is delivered as a message. Otherwise it runs right away.
+//
+// Parameters:
+//   - result (func(err error)): Called on the event loop with the result of the validation.
+func (p *Prompt[TValue]) validate(result func(err error)) {
+	if p.Validate == nil && p.LiveValidate == nil {
+		result(nil)
+		return
+	}
+
+	p.State = ValidateState
+	p.IsValidating = true
+	p.ValidationDuration = 0
+	p.Emit(ValidateEvent, p.Value)
+
+	ctx, value, validate, liveValidate := p.context, p.Value, p.Validate, p.LiveValidate
+	run := func() error {
+		if validate != nil {
+			if err := validate(value); err != nil {
+				return err
+			}
+		}
+		if liveValidate != nil {
+			return liveValidate(ctx, value)
+		}
+		return nil
+	}
+
+	if !p.isRunning() {
+		err := run()
+		p.IsValidating = false
+		result(err)
+		return
+	}
+
+	p.validation++
+	validation, start := p.validation, time.Now()
+	var tick func()
+	tick = func() {
+		if validation != p.validation || !p.IsValidating {
+			return
+		}
+		p.ValidationDuration = time.Since(start)
+		p.render()
+		p.validationTimer = time.AfterFunc(validationRenderInterval, func() { p.post(tick) })
+	}
+	p.validationTimer = time.AfterFunc(validationRenderDelay, func() { p.post(tick) })
+
+	go func() {
+		err := run()
+		p.post(func() {
+			if validation != p.validation {
+				return
+			}
+			p.stopValidation()
+			result(err)
+		})
+	}()
+}
+
+// stopValidation stops the validation in progress, whose result is then discarded.
+func (p *Prompt[TValue]) stopValidation() {
+	p.validation++
+	p.IsValidating = false
+	if p.validationTimer != nil {
+		p.validationTimer.Stop()
+	}
+}
+
+// scheduleLiveValidation validates the value live once it stops changing for LiveValidateDelay.
+// The validation in progress for a previous value is cancelled through its context, and its result is discarded.
+// The result is delivered to the event loop and rendered inline as an error, without blocking the input.
+func (p *Prompt[TValue]) scheduleLiveValidation() {
+	if p.LiveValidate == nil || !p.isRunning() || reflect.DeepEqual(p.Value, p.liveValue) {
+		return
+	}
+	p.liveValue = p.Value
+	p.stopLiveValidation()
+
+	ctx, cancel := context.WithCancel(p.context)
+	p.liveCancel = cancel
+	value, validate := p.Value, p.LiveValidate
+	p.liveTimer = time.AfterFunc(p.LiveValidateDelay, func() {
+		err := validate(ctx, value)
+		p.post(func() {
+			if ctx.Err() == nil {
+				p.applyLiveValidation(err)
+			}
+		})
+	})
+}
+
+// stopLiveValidation cancels the scheduled or running live validation.
+func (p *Prompt[TValue]) stopLiveValidation() {
+	if p.liveTimer != nil {
+		p.liveTimer.Stop()
+	}
+	if p.liveCancel != nil {
+		p.liveCancel()
+	}
+}
+
+// applyLiveValidation renders the result of a live validation.
+// An error is shown in the ErrorState, which is cleared once the value is valid again.
+func (p *Prompt[TValue]) applyLiveValidation(err error) {
+	if p.State == SubmitState || p.State == CancelState || p.IsValidating {
+		return
+	}
+	if err != nil {
+		p.State = ErrorState
+		p.Error = err.Error()
+		p.Emit(ErrorEvent, err)
+	} else if p.State == ErrorState {
+		p.State = ActiveState
+		p.Error = ""
+	}
+	p.render()
+}
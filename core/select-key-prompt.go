@@ -2,8 +2,10 @@ package core
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"os"
+	"io"
+	"time"
 
 	"github.com/orochaa/go-clack/core/validator"
 )
@@ -33,11 +35,17 @@ type SelectKeyPrompt[TValue any] struct {
 }
 
 type SelectKeyPromptParams[TValue any] struct {
-	Context context.Context
-	Input   *os.File
-	Output  *os.File
-	Options []*SelectKeyOption[TValue]
-	Render  func(p *SelectKeyPrompt[TValue]) string
+	Context       context.Context
+	Input         io.Reader
+	Output        io.Writer
+	Options       []*SelectKeyOption[TValue]
+	Timeout       time.Duration
+	TimeoutCancel bool
+	Ephemeral     bool
+	LineMode      bool
+	Summary       func(value TValue) string
+	Settings      *SettingsOptions
+	Render        func(p *SelectKeyPrompt[TValue]) string
 }
 
 // NewSelectKeyPrompt initializes and returns a new instance of SelectKeyPrompt.
@@ -50,9 +58,15 @@ type SelectKeyPromptParams[TValue any] struct {
 //
 // Parameters:
 //   - Context (context.Context): The context for the prompt (default: context.Background).
-//   - Input (*os.File): The input stream for the prompt (default: OSFileSystem).
-//   - Output (*os.File): The output stream for the prompt (default: OSFileSystem).
+//   - Input (io.Reader): The input stream for the prompt (default: os.Stdin).
+//   - Output (io.Writer): The output stream for the prompt (default: os.Stdout).
 //   - Options ([]*SelectKeyOption[TValue]): A list of options for the prompt (default: nil).
+//   - Timeout (time.Duration): The time after which the current value is submitted, unless the user presses a key before (default: 0, no timeout).
+//   - TimeoutCancel (bool): Whether to cancel the prompt with ErrTimeout instead of submitting the current value on timeout (default: false).
+//   - Ephemeral (bool): Whether to erase the prompt once submitted, or replace it by its Summary (default: false).
+//   - LineMode (bool): Whether to read whole lines as answers, without raw mode or cursor movements, as done when the input is not a terminal (default: false).
+//   - Summary (func(value TValue) string): The one-line summary of the submitted value of an ephemeral prompt (default: nil, the prompt is erased).
+//   - Settings (*SettingsOptions): The key bindings and options of the prompt (default: the global Settings).
 //   - Render (func(p *SelectKeyPrompt[TValue]) string): Custom render function for the prompt (default: nil).
 //
 // Returns:
@@ -71,17 +85,22 @@ func NewSelectKeyPrompt[TValue any](params SelectKeyPromptParams[TValue]) *Selec
 	var p SelectKeyPrompt[TValue]
 	p = SelectKeyPrompt[TValue]{
 		Prompt: *NewPrompt(PromptParams[TValue]{
-			Context: params.Context,
-			Input:   params.Input,
-			Output:  params.Output,
-			Render:  WrapRender[TValue](&p, params.Render),
+			Context:       params.Context,
+			Input:         params.Input,
+			Output:        params.Output,
+			ParseLine:     p.parseLine,
+			Timeout:       params.Timeout,
+			TimeoutCancel: params.TimeoutCancel,
+			Ephemeral:     params.Ephemeral,
+			LineMode:      params.LineMode,
+			Summary:       params.Summary,
+			Settings:      params.Settings,
+			Render:        WrapRender[TValue](&p, params.Render),
 		}),
 		Options: params.Options,
 	}
 
-	p.On(KeyEvent, func(args ...any) {
-		p.handleKeyPress(args[0].(*Key))
-	})
+	p.OnKey(p.handleKeyPress)
 
 	return &p
 }
@@ -93,7 +112,7 @@ func NewSelectKeyPrompt[TValue any](params SelectKeyPromptParams[TValue]) *Selec
 //   - key (*Key): The key event to process.
 func (p *SelectKeyPrompt[TValue]) handleKeyPress(key *Key) {
 	for i, option := range p.Options {
-		if key.Name == KeyName(option.Key) {
+		if key.Binding() == KeyName(option.Key) {
 			p.State = SubmitState
 			p.Value = option.Value
 			p.CursorIndex = i
@@ -104,3 +123,28 @@ func (p *SelectKeyPrompt[TValue]) handleKeyPress(key *Key) {
 		key.Name = ""
 	}
 }
+
+// parseLine parses a line answer into the value of the option with the matching key or label.
+//
+// Parameters:
+//   - line (string): The line answer to parse.
+//
+// Returns:
+//   - TValue: The value of the matching option.
+//   - error: An error if no option matches the answer.
+func (p *SelectKeyPrompt[TValue]) parseLine(line string) (TValue, error) {
+	keys := make([]string, len(p.Options))
+	labels := make([]string, len(p.Options))
+	for i, option := range p.Options {
+		keys[i] = option.Key
+		labels[i] = option.Label
+	}
+
+	index := matchLineOption(line, keys, labels)
+	if index == -1 {
+		return p.Value, errors.New("Please select one of the available options.")
+	}
+
+	p.CursorIndex = index
+	return p.Options[index].Value, nil
+}
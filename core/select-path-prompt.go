@@ -2,8 +2,9 @@ package core
 
 import (
 	"context"
-	"os"
+	"io"
 	"path"
+	"time"
 
 	"github.com/orochaa/go-clack/core/internals"
 	"github.com/orochaa/go-clack/core/utils"
@@ -22,14 +23,21 @@ type SelectPathPrompt struct {
 }
 
 type SelectPathPromptParams struct {
-	Context      context.Context
-	Input        *os.File
-	Output       *os.File
-	InitialValue string
-	OnlyShowDir  bool
-	Filter       bool
-	FileSystem   FileSystem
-	Render       func(p *SelectPathPrompt) string
+	Context       context.Context
+	Input         io.Reader
+	Output        io.Writer
+	InitialValue  string
+	OnlyShowDir   bool
+	Filter        bool
+	FileSystem    FileSystem
+	Mouse         bool
+	Timeout       time.Duration
+	TimeoutCancel bool
+	Ephemeral     bool
+	LineMode      bool
+	Summary       func(value string) string
+	Settings      *SettingsOptions
+	Render        func(p *SelectPathPrompt) string
 }
 
 // NewSelectPathPrompt initializes and returns a new instance of SelectPathPrompt.
@@ -42,12 +50,19 @@ type SelectPathPromptParams struct {
 //
 // Parameters:
 //   - Context (context.Context): The context for the prompt (default: context.Background).
-//   - Input (*os.File): The input stream for the prompt (default: OSFileSystem).
-//   - Output (*os.File): The output stream for the prompt (default: OSFileSystem).
+//   - Input (io.Reader): The input stream for the prompt (default: os.Stdin).
+//   - Output (io.Writer): The output stream for the prompt (default: os.Stdout).
 //   - InitialValue (string): The initial path value (default: current working directory).
 //   - OnlyShowDir (bool): Whether to only show directories (default: false).
 //   - Filter (bool): Whether to enable filtering of options (default: false).
 //   - FileSystem (FileSystem): The file system implementation to use (default: OSFileSystem).
+//   - Mouse (bool): Whether to select options by clicking and scroll them with the wheel (default: Settings.Mouse).
+//   - Timeout (time.Duration): The time after which the current value is submitted, unless the user presses a key before (default: 0, no timeout).
+//   - TimeoutCancel (bool): Whether to cancel the prompt with ErrTimeout instead of submitting the current value on timeout (default: false).
+//   - Ephemeral (bool): Whether to erase the prompt once submitted, or replace it by its Summary (default: false).
+//   - LineMode (bool): Whether to read whole lines as answers, without raw mode or cursor movements, as done when the input is not a terminal (default: false).
+//   - Summary (func(value string) string): The one-line summary of the submitted value of an ephemeral prompt (default: nil, the prompt is erased).
+//   - Settings (*SettingsOptions): The key bindings and options of the prompt (default: the global Settings).
 //   - Render (func(p *SelectPathPrompt) string): Custom render function for the prompt (default: nil).
 //
 // Returns:
@@ -63,11 +78,19 @@ func NewSelectPathPrompt(params SelectPathPromptParams) *SelectPathPrompt {
 	var p SelectPathPrompt
 	p = SelectPathPrompt{
 		Prompt: *NewPrompt(PromptParams[string]{
-			Context:     params.Context,
-			Input:       params.Input,
-			Output:      params.Output,
-			CursorIndex: 1,
-			Render:      WrapRender[string](&p, params.Render),
+			Context:       params.Context,
+			Input:         params.Input,
+			Output:        params.Output,
+			CursorIndex:   1,
+			ParseLine:     p.parseLine,
+			Mouse:         params.Mouse || resolveSettings(params.Settings).Mouse,
+			Timeout:       params.Timeout,
+			TimeoutCancel: params.TimeoutCancel,
+			Ephemeral:     params.Ephemeral,
+			LineMode:      params.LineMode,
+			Summary:       params.Summary,
+			Settings:      params.Settings,
+			Render:        WrapRender[string](&p, params.Render),
 		}),
 		OnlyShowDir: params.OnlyShowDir,
 		Filter:      params.Filter,
@@ -85,7 +108,7 @@ func NewSelectPathPrompt(params SelectPathPromptParams) *SelectPathPrompt {
 	p.CurrentOption = p.Root.Children[0]
 	p.Value = p.CurrentOption.Path
 
-	actionHandler := NewActionHandler(map[Action]func(){
+	actionHandler := p.NewActionHandler(map[Action]func(){
 		UpAction:    func() { p.moveCursor(-1) },
 		DownAction:  func() { p.moveCursor(1) },
 		LeftAction:  p.closeNode,
@@ -102,15 +125,24 @@ func NewSelectPathPrompt(params SelectPathPromptParams) *SelectPathPrompt {
 				p.CursorIndex = p.Root.IndexOf(p.CurrentOption, p.Options())
 			}
 		},
+		HalfPageUpAction:   func() { p.movePage(-p.halfPage()) },
+		HalfPageDownAction: func() { p.movePage(p.halfPage()) },
+		PageUpAction:       func() { p.movePage(-p.pageSize()) },
+		PageDownAction:     func() { p.movePage(p.pageSize()) },
 	}, p.filterOptions)
-	p.On(KeyEvent, func(args ...any) {
-		actionHandler(args[0].(*Key))
-
-		if p.CurrentOption != nil {
-			p.Value = p.CurrentOption.Path
-		} else {
-			p.Value = *new(string)
-		}
+	p.typesKeys = func() bool { return p.Filter }
+	p.OnKey(func(key *Key) {
+		actionHandler(key)
+		p.updateValue()
+	})
+	p.OnMouse(func(mouse *Mouse) {
+		p.handleMouse(mouse, p.moveCursor, func(index int) {
+			if options := p.Options(); index < len(options) {
+				p.CurrentOption = options[index]
+				p.CursorIndex = index
+			}
+		})
+		p.updateValue()
 	})
 
 	return &p
@@ -124,6 +156,15 @@ func (p *SelectPathPrompt) Options() []*PathNode {
 	return p.Root.FilteredFlat(p.Search, p.CurrentOption)
 }
 
+// updateValue sets the value to the path of the current option, or to an empty string if there is none.
+func (p *SelectPathPrompt) updateValue() {
+	if p.CurrentOption != nil {
+		p.Value = p.CurrentOption.Path
+	} else {
+		p.Value = *new(string)
+	}
+}
+
 // moveCursor moves the cursor up or down within the current layer of options.
 //
 // Parameters:
@@ -136,6 +177,18 @@ func (p *SelectPathPrompt) moveCursor(direction int) {
 	}
 }
 
+// movePage moves the cursor by a number of options within the current layer, stopping at its first and last options.
+//
+// Parameters:
+//   - offset (int): The number of options to move the cursor by, negative to move it up.
+func (p *SelectPathPrompt) movePage(offset int) {
+	if layerOptions := p.CurrentOption.FilteredLayer(p.Search); len(layerOptions) > 0 {
+		layerIndex := p.Root.IndexOf(p.CurrentOption, layerOptions)
+		p.CurrentOption = layerOptions[clampIndex(layerIndex, offset, len(layerOptions))]
+		p.CursorIndex = p.Root.IndexOf(p.CurrentOption, p.Options())
+	}
+}
+
 // closeNode closes the currently selected node or moves up to the parent directory.
 func (p *SelectPathPrompt) closeNode() {
 	p.Search = ""
@@ -180,7 +233,7 @@ func (p *SelectPathPrompt) filterOptions(key *Key) {
 		return
 	}
 
-	p.Search, _ = p.TrackKeyValue(key, p.Search, len(p.Search))
+	p.Search, _ = p.TrackKeyValue(key, p.Search, utils.GraphemeCount(p.Search))
 	if p.CurrentOption.IsRoot() {
 		return
 	}
@@ -194,3 +247,22 @@ func (p *SelectPathPrompt) filterOptions(key *Key) {
 	}
 	p.CursorIndex = p.Root.IndexOf(p.CurrentOption, options)
 }
+
+// parseLine parses a line answer into the selected path.
+// An empty answer keeps the current value, while a path which does not exist, or is not a directory if OnlyShowDir is set, is rejected.
+//
+// Parameters:
+//   - line (string): The line answer to parse.
+//
+// Returns:
+//   - string: The selected path.
+//   - error: An error if a path does not exist, nil otherwise.
+func (p *SelectPathPrompt) parseLine(line string) (string, error) {
+	if line == "" {
+		return p.Value, nil
+	}
+	if err := checkLinePath(p.FileSystem, line, p.OnlyShowDir); err != nil {
+		return p.Value, err
+	}
+	return line, nil
+}
@@ -1,7 +1,9 @@
 package core_test
 
 import (
+	"bytes"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/orochaa/go-clack/core"
@@ -163,3 +165,21 @@ func TestSelectPathFilterRecover(t *testing.T) {
 	p.PressKey(&core.Key{Name: core.BackspaceKey})
 	assert.Equal(t, 3, len(p.Options()))
 }
+
+func TestSelectPathLineModeWithUnknownPath(t *testing.T) {
+	dir := t.TempDir()
+	p := core.NewSelectPathPrompt(core.SelectPathPromptParams{
+		Input:  strings.NewReader(filepath.Join(dir, "unknown") + "\n" + dir + "\n"),
+		Output: &bytes.Buffer{},
+		Render: func(p *core.SelectPathPrompt) string { return "" },
+	})
+	p.LineMode = true
+
+	var errorMessage string
+	p.On(core.ErrorEvent, func(args ...any) { errorMessage = args[0].(error).Error() })
+
+	value, err := p.Run()
+	assert.NoError(t, err)
+	assert.Equal(t, dir, value)
+	assert.Equal(t, "Path does not exist: "+filepath.Join(dir, "unknown"), errorMessage)
+}
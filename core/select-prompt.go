@@ -2,9 +2,12 @@ package core
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"os"
+	"io"
 	"regexp"
+	"strings"
+	"time"
 
 	"github.com/orochaa/go-clack/core/utils"
 	"github.com/orochaa/go-clack/core/validator"
@@ -27,6 +30,9 @@ func (o *SelectOption[TValue]) String() string {
 	)
 }
 
+// DefaultTypeAheadTimeout is the time after which a type-ahead prefix is reset if no other key is typed.
+const DefaultTypeAheadTimeout = time.Second
+
 type SelectPrompt[TValue comparable] struct {
 	Prompt[TValue]
 	initialOptions []*SelectOption[TValue]
@@ -34,22 +40,35 @@ type SelectPrompt[TValue comparable] struct {
 	Search         string
 	Filter         bool
 	Required       bool
+
+	TypeAhead        string
+	TypeAheadTimeout time.Duration
+	typeAheadTime    time.Time
 }
 
 type SelectPromptParams[TValue comparable] struct {
-	Context      context.Context
-	Input        *os.File
-	Output       *os.File
-	InitialValue TValue
-	Options      []*SelectOption[TValue]
-	Filter       bool
-	Required     bool
-	Render       func(p *SelectPrompt[TValue]) string
+	Context          context.Context
+	Input            io.Reader
+	Output           io.Writer
+	InitialValue     TValue
+	Options          []*SelectOption[TValue]
+	Filter           bool
+	Required         bool
+	Mouse            bool
+	Timeout          time.Duration
+	TimeoutCancel    bool
+	TypeAheadTimeout time.Duration
+	Ephemeral        bool
+	LineMode         bool
+	Summary          func(value TValue) string
+	Settings         *SettingsOptions
+	Render           func(p *SelectPrompt[TValue]) string
 }
 
 // NewSelectPrompt initializes and returns a new instance of SelectPrompt.
 //
 // The user can navigate through options using arrow keys.
+// Without filter, the user can also jump to an option by typing the start of its label.
 // The user can select an option using enter key.
 // The prompt returns the value of the selected option.
 // If the user cancels the prompt, it returns an error.
@@ -57,12 +76,20 @@ type SelectPromptParams[TValue comparable] struct {
 //
 // Parameters:
 //   - Context (context.Context): The context for the prompt (default: context.Background).
-//   - Input (*os.File): The input stream for the prompt (default: OSFileSystem).
-//   - Output (*os.File): The output stream for the prompt (default: OSFileSystem).
+//   - Input (io.Reader): The input stream for the prompt (default: os.Stdin).
+//   - Output (io.Writer): The output stream for the prompt (default: os.Stdout).
 //   - InitialValue (TValue): The initial value of the prompt (default: zero value of TValue).
 //   - Options ([]*SelectOption[TValue]): A list of options for the prompt (default: nil).
 //   - Filter (bool): Whether to enable filtering of options (default: false).
 //   - Required (bool): Whether the prompt requires a selection (default: false).
+//   - Mouse (bool): Whether to select options by clicking and scroll them with the wheel (default: Settings.Mouse).
+//   - Timeout (time.Duration): The time after which the current value is submitted, unless the user presses a key before (default: 0, no timeout).
+//   - TimeoutCancel (bool): Whether to cancel the prompt with ErrTimeout instead of submitting the current value on timeout (default: false).
+//   - TypeAheadTimeout (time.Duration): The time after which the typed prefix is reset if no other key is typed (default: DefaultTypeAheadTimeout).
+//   - Ephemeral (bool): Whether to erase the prompt once submitted, or replace it by its Summary (default: false).
+//   - LineMode (bool): Whether to read whole lines as answers, without raw mode or cursor movements, as done when the input is not a terminal (default: false).
+//   - Summary (func(value TValue) string): The one-line summary of the submitted value of an ephemeral prompt (default: nil, the prompt is erased).
+//   - Settings (*SettingsOptions): The key bindings and options of the prompt (default: the global Settings).
 //   - Render (func(p *SelectPrompt[TValue]) string): Custom render function for the prompt (default: nil).
 //
 // Returns:
@@ -82,44 +109,80 @@ func NewSelectPrompt[TValue comparable](params SelectPromptParams[TValue]) *Sele
 		}
 	}
 
+	if params.TypeAheadTimeout == 0 {
+		params.TypeAheadTimeout = DefaultTypeAheadTimeout
+	}
+
 	var p SelectPrompt[TValue]
 	p = SelectPrompt[TValue]{
 		Prompt: *NewPrompt(PromptParams[TValue]{
-			Context:      params.Context,
-			Input:        params.Input,
-			Output:       params.Output,
-			InitialValue: params.Options[startIndex].Value,
-			CursorIndex:  startIndex,
-			Validate:     WrapValidate[TValue](nil, &p.Required, "Please select an option."),
-			Render:       WrapRender[TValue](&p, params.Render),
+			Context:       params.Context,
+			Input:         params.Input,
+			Output:        params.Output,
+			InitialValue:  params.Options[startIndex].Value,
+			CursorIndex:   startIndex,
+			Validate:      WrapValidate[TValue](nil, &p.Required, "Please select an option."),
+			ParseLine:     p.parseLine,
+			Mouse:         params.Mouse || resolveSettings(params.Settings).Mouse,
+			Timeout:       params.Timeout,
+			TimeoutCancel: params.TimeoutCancel,
+			Ephemeral:     params.Ephemeral,
+			LineMode:      params.LineMode,
+			Summary:       params.Summary,
+			Settings:      params.Settings,
+			Render:        WrapRender[TValue](&p, params.Render),
 		}),
-		initialOptions: params.Options,
-		Options:        params.Options,
-		Filter:         params.Filter,
-		Required:       params.Required,
-	}
-
-	actionHandler := NewActionHandler(map[Action]func(){
-		UpAction:    func() { p.moveCursor(-1) },
-		DownAction:  func() { p.moveCursor(1) },
-		LeftAction:  func() { p.moveCursor(-1) },
-		RightAction: func() { p.moveCursor(1) },
-		HomeAction:  func() { p.CursorIndex = 0 },
-		EndAction:   func() { p.CursorIndex = len(p.Options) - 1 },
-	}, p.filterOptions)
-	p.On(KeyEvent, func(args ...any) {
-		actionHandler(args[0].(*Key))
-
-		if p.CursorIndex >= 0 && p.CursorIndex < len(p.Options) {
-			p.Value = p.Options[p.CursorIndex].Value
+		initialOptions:   params.Options,
+		Options:          params.Options,
+		Filter:           params.Filter,
+		Required:         params.Required,
+		TypeAheadTimeout: params.TypeAheadTimeout,
+	}
+
+	actionHandler := p.NewActionHandler(map[Action]func(){
+		UpAction:           func() { p.moveCursor(-1) },
+		DownAction:         func() { p.moveCursor(1) },
+		LeftAction:         func() { p.moveCursor(-1) },
+		RightAction:        func() { p.moveCursor(1) },
+		HomeAction:         func() { p.CursorIndex = 0 },
+		EndAction:          func() { p.CursorIndex = len(p.Options) - 1 },
+		HalfPageUpAction:   func() { p.movePage(-p.halfPage()) },
+		HalfPageDownAction: func() { p.movePage(p.halfPage()) },
+		PageUpAction:       func() { p.movePage(-p.pageSize()) },
+		PageDownAction:     func() { p.movePage(p.pageSize()) },
+	}, func(key *Key) {
+		if p.Filter {
+			p.filterOptions(key)
 		} else {
-			p.Value = *new(TValue)
+			p.typeAhead(key)
 		}
 	})
+	p.typesKeys = func() bool { return p.Filter }
+	p.OnKey(func(key *Key) {
+		actionHandler(key)
+		p.updateValue()
+	})
+	p.OnMouse(func(mouse *Mouse) {
+		p.handleMouse(mouse, p.moveCursor, func(index int) {
+			if index < len(p.Options) {
+				p.CursorIndex = index
+			}
+		})
+		p.updateValue()
+	})
 
 	return &p
 }
 
+// updateValue sets the value to the option under the cursor, or to the zero value if there is none.
+func (p *SelectPrompt[TValue]) updateValue() {
+	if p.CursorIndex >= 0 && p.CursorIndex < len(p.Options) {
+		p.Value = p.Options[p.CursorIndex].Value
+	} else {
+		p.Value = *new(TValue)
+	}
+}
+
 // moveCursor moves the cursor up or down within the list of options.
 // It ensures the cursor stays within the bounds of the available options.
 //
@@ -129,6 +192,51 @@ func (p *SelectPrompt[TValue]) moveCursor(direction int) {
 	p.CursorIndex = utils.MinMaxIndex(p.CursorIndex+direction, len(p.Options))
 }
 
+// movePage moves the cursor by a number of options, stopping at the first and last options.
+//
+// Parameters:
+//   - offset (int): The number of options to move the cursor by, negative to move it up.
+func (p *SelectPrompt[TValue]) movePage(offset int) {
+	p.CursorIndex = clampIndex(p.CursorIndex, offset, len(p.Options))
+}
+
+// typeAhead jumps the cursor to the next option whose label starts with the typed prefix, as in native list boxes.
+// The prefix grows with each key typed within TypeAheadTimeout of the previous one, and is reset otherwise.
+// Typing the same character repeatedly cycles through the options starting with it.
+//
+// Parameters:
+//   - key (*Key): The typed key.
+func (p *SelectPrompt[TValue]) typeAhead(key *Key) {
+	char := key.Char
+	if key.Name == SpaceKey && !key.Ctrl && p.TypeAhead != "" {
+		char = " "
+	}
+	if char == "" || key.Ctrl || key.Alt || key.Name == PasteKey {
+		return
+	}
+
+	now := time.Now()
+	if now.Sub(p.typeAheadTime) > p.TypeAheadTimeout {
+		p.TypeAhead = ""
+	}
+	p.typeAheadTime = now
+	char = strings.ToLower(char)
+	p.TypeAhead += char
+
+	// A longer prefix may still match the current option, while a new or repeated character moves to the next one
+	prefix, start := p.TypeAhead, p.CursorIndex
+	if strings.Trim(prefix, char) == "" {
+		prefix, start = char, p.CursorIndex+1
+	}
+	for i := range p.Options {
+		index := (start + i) % len(p.Options)
+		if strings.HasPrefix(strings.ToLower(p.Options[index].Label), prefix) {
+			p.CursorIndex = index
+			return
+		}
+	}
+}
+
 // filterOptions updates the search term based on the provided key input and filters the available options.
 // If the search term is empty, it resets the options to the initial list.
 //
@@ -139,7 +247,7 @@ func (p *SelectPrompt[TValue]) filterOptions(key *Key) {
 		return
 	}
 
-	p.Search, _ = p.TrackKeyValue(key, p.Search, len(p.Search))
+	p.Search, _ = p.TrackKeyValue(key, p.Search, utils.GraphemeCount(p.Search))
 	p.CursorIndex = 0
 
 	if p.Search == "" {
@@ -168,3 +276,34 @@ func (p *SelectPrompt[TValue]) filterOptions(key *Key) {
 		}
 	}
 }
+
+// parseLine parses a line answer into the value of the matching option.
+// The answer may be the option's label, its value or its 1-based position.
+// An empty answer keeps the current value.
+//
+// Parameters:
+//   - line (string): The line answer to parse.
+//
+// Returns:
+//   - TValue: The value of the matching option.
+//   - error: An error if no option matches the answer.
+func (p *SelectPrompt[TValue]) parseLine(line string) (TValue, error) {
+	if line == "" {
+		return p.Value, nil
+	}
+
+	labels := make([]string, len(p.Options))
+	values := make([]string, len(p.Options))
+	for i, option := range p.Options {
+		labels[i] = option.Label
+		values[i] = fmt.Sprint(option.Value)
+	}
+
+	index := matchLineOption(line, labels, values)
+	if index == -1 {
+		return p.Value, errors.New("Please select one of the available options.")
+	}
+
+	p.CursorIndex = index
+	return p.Options[index].Value, nil
+}
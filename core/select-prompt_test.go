@@ -1,9 +1,14 @@
 package core_test
 
 import (
+	"bytes"
+	"fmt"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/orochaa/go-clack/core"
+	"github.com/orochaa/go-clack/core/utils"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -134,6 +139,25 @@ func TestSelectFilterCursor(t *testing.T) {
 	assert.Equal(t, 2, p.CursorIndex)
 }
 
+func TestSelectFilterUndo(t *testing.T) {
+	p := newSelectPrompt()
+	p.Filter = true
+	optionsCount := len(p.Options)
+
+	p.PressKey(&core.Key{Char: "b"})
+	p.PressKey(&core.Key{Char: "a"})
+	assert.Equal(t, "ba", p.Search)
+	assert.Less(t, len(p.Options), optionsCount)
+
+	p.PressKey(&core.Key{Name: "z", Ctrl: true})
+	assert.Equal(t, "", p.Search)
+	assert.Equal(t, optionsCount, len(p.Options))
+
+	p.PressKey(&core.Key{Name: "z", Ctrl: true, Shift: true})
+	assert.Equal(t, "ba", p.Search)
+	assert.Less(t, len(p.Options), optionsCount)
+}
+
 func TestSelectFilterOutOptions(t *testing.T) {
 	p := newSelectPrompt()
 	p.Filter = true
@@ -152,3 +176,235 @@ func TestSelectFilterOutOptions(t *testing.T) {
 	p.PressKey(&core.Key{Name: core.BackspaceKey})
 	assert.Equal(t, 2, p.CursorIndex)
 }
+
+func TestSelectPromptLineMode(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected string
+	}{
+		{input: "bar", expected: "bar"},
+		{input: "BAZ", expected: "baz"},
+		{input: "1", expected: "foo"},
+		{input: "", expected: "foo"},
+		{input: "qux\n3", expected: "baz"},
+	}
+
+	for _, tC := range testCases {
+		p := core.NewSelectPrompt(core.SelectPromptParams[string]{
+			Input:  strings.NewReader(tC.input + "\n"),
+			Output: &bytes.Buffer{},
+			Options: []*core.SelectOption[string]{
+				{Label: "foo"},
+				{Label: "bar"},
+				{Label: "baz"},
+			},
+			Render: func(p *core.SelectPrompt[string]) string { return "" },
+		})
+		p.LineMode = true
+
+		value, err := p.Run()
+		assert.NoError(t, err)
+		assert.Equal(t, tC.expected, value)
+	}
+}
+
+func newMouseSelectPrompt(input string, height int) *core.SelectPrompt[string] {
+	return core.NewSelectPrompt(core.SelectPromptParams[string]{
+		Input:  strings.NewReader(input),
+		Output: &MockTerminal{Width: 80, Height: height},
+		Mouse:  true,
+		Options: []*core.SelectOption[string]{
+			{Label: "foo"},
+			{Label: "bar"},
+			{Label: "baz"},
+		},
+		Render: func(p *core.SelectPrompt[string]) string {
+			lines := make([]string, len(p.Options))
+			for i, option := range p.Options {
+				lines[i] = "> " + option.Label
+			}
+			return "Select\r\n" + p.LimitLines(lines, 1)
+		},
+	})
+}
+
+func TestSelectPromptMouseClick(t *testing.T) {
+	p := newMouseSelectPrompt("\x1b[10;1R\x1b[<0;3;12M\x1b[<0;3;12m\r", 40)
+
+	value, err := p.Run()
+	assert.NoError(t, err)
+	assert.Equal(t, "bar", value)
+	assert.Equal(t, 1, p.CursorIndex)
+}
+
+func TestSelectPromptMouseClickAfterScroll(t *testing.T) {
+	// The frame has 4 lines, so a terminal of 5 rows scrolls it up to the second row
+	p := newMouseSelectPrompt("\x1b[10;1R\x1b[<0;3;5M\r", 5)
+
+	value, err := p.Run()
+	assert.NoError(t, err)
+	assert.Equal(t, "baz", value)
+}
+
+func TestSelectPromptMouseClickWithOptionInMessage(t *testing.T) {
+	p := core.NewSelectPrompt(core.SelectPromptParams[string]{
+		Input:  strings.NewReader("\x1b[10;1R\x1b[B\x1b[<0;3;11M\r"),
+		Output: &MockTerminal{Width: 80, Height: 40},
+		Mouse:  true,
+		Options: []*core.SelectOption[string]{
+			{Label: "foo"},
+			{Label: "bar"},
+		},
+		Render: func(p *core.SelectPrompt[string]) string {
+			lines := make([]string, len(p.Options))
+			for i, option := range p.Options {
+				lines[i] = "> " + option.Label
+			}
+			return "Select (default: > foo)\r\n" + p.LimitLines(lines, 1)
+		},
+	})
+
+	value, err := p.Run()
+	assert.NoError(t, err)
+	assert.Equal(t, "foo", value)
+}
+
+func TestSelectPromptMouseClickWithFormattedLines(t *testing.T) {
+	output := &MockTerminal{Width: 20, Height: 40}
+	p := core.NewSelectPrompt(core.SelectPromptParams[string]{
+		Input:  strings.NewReader("\x1b[10;1R\x1b[<0;3;15M\r"),
+		Output: output,
+		Mouse:  true,
+		Options: []*core.SelectOption[string]{
+			{Label: "foo"},
+			{Label: "bar with a label wrapped by the theme"},
+			{Label: "baz"},
+		},
+		Render: func(p *core.SelectPrompt[string]) string {
+			lines := make([]string, len(p.Options))
+			for i, option := range p.Options {
+				lines[i] = "> " + option.Label
+			}
+			return "Select\r\n" + p.FormatLines(utils.SplitLines(p.LimitLines(lines, 1)), core.FormatLinesOptions{
+				Default: core.FormatLineOptions{Start: "|"},
+			})
+		},
+	})
+
+	value, err := p.Run()
+	assert.NoError(t, err)
+	assert.Equal(t, "baz", value)
+	assert.NotContains(t, output.String(), "clack-option")
+}
+
+func TestSelectPromptMouseClickOutsideOptions(t *testing.T) {
+	p := newMouseSelectPrompt("\x1b[10;1R\x1b[<0;3;10M\x1b[<0;3;20M\r", 40)
+
+	value, err := p.Run()
+	assert.NoError(t, err)
+	assert.Equal(t, "foo", value)
+}
+
+func TestSelectPromptMouseWheel(t *testing.T) {
+	p := newMouseSelectPrompt("\x1b[<65;1;1M\x1b[<65;1;1M\x1b[<64;1;1M\r", 40)
+
+	value, err := p.Run()
+	assert.NoError(t, err)
+	assert.Equal(t, "bar", value)
+}
+
+func TestSelectPromptPageNavigation(t *testing.T) {
+	const pageDown, pageUp = "\x1b[6~", "\x1b[5~"
+	testCases := []struct {
+		description string
+		input       string
+		expected    string
+	}{
+		// The window renders 10 lines, of which 9 options at the top and 8 options between ellipses
+		{description: "page down", input: pageDown, expected: "option 9"},
+		{description: "page down twice", input: pageDown + pageDown, expected: "option 17"},
+		{description: "page up", input: pageDown + pageDown + pageUp, expected: "option 9"},
+		{description: "page down at the end", input: "\x1b[F" + pageDown, expected: "option 29"},
+		{description: "page up at the start", input: pageDown + pageUp + pageUp, expected: "option 0"},
+	}
+
+	for _, tC := range testCases {
+		t.Run(tC.description, func(t *testing.T) {
+			options := make([]*core.SelectOption[string], 30)
+			for i := range options {
+				options[i] = &core.SelectOption[string]{Label: fmt.Sprintf("option %d", i)}
+			}
+			p := core.NewSelectPrompt(core.SelectPromptParams[string]{
+				Input:   strings.NewReader(tC.input + "\r"),
+				Output:  &MockTerminal{Width: 80, Height: 11},
+				Options: options,
+				Render: func(p *core.SelectPrompt[string]) string {
+					lines := make([]string, len(p.Options))
+					for i, option := range p.Options {
+						lines[i] = option.Label
+					}
+					return "Select\r\n" + p.LimitLines(lines, 1)
+				},
+			})
+
+			value, err := p.Run()
+			assert.NoError(t, err)
+			assert.Equal(t, tC.expected, value)
+		})
+	}
+}
+
+func TestSelectTypeAheadTimeout(t *testing.T) {
+	options := []*core.SelectOption[string]{{Label: "foo"}}
+	render := func(p *core.SelectPrompt[string]) string { return "" }
+
+	p := core.NewSelectPrompt(core.SelectPromptParams[string]{Options: options, Render: render})
+	assert.Equal(t, core.DefaultTypeAheadTimeout, p.TypeAheadTimeout)
+
+	p = core.NewSelectPrompt(core.SelectPromptParams[string]{Options: options, TypeAheadTimeout: time.Minute, Render: render})
+	assert.Equal(t, time.Minute, p.TypeAheadTimeout)
+}
+
+func TestSelectTypeAhead(t *testing.T) {
+	p := core.NewSelectPrompt(core.SelectPromptParams[string]{
+		Options: []*core.SelectOption[string]{
+			{Label: "Apple"},
+			{Label: "Banana"},
+			{Label: "Blueberry"},
+			{Label: "Cherry"},
+			{Label: "Blackberry"},
+		},
+		Render: func(p *core.SelectPrompt[string]) string { return "" },
+	})
+	typeKey := func(char string) {
+		p.PressKey(&core.Key{Name: core.KeyName(char), Char: char})
+	}
+
+	typeKey("b")
+	assert.Equal(t, "Banana", p.Value)
+	typeKey("l")
+	assert.Equal(t, "Blueberry", p.Value)
+	typeKey("a")
+	assert.Equal(t, "Blackberry", p.Value)
+	assert.Equal(t, "bla", p.TypeAhead)
+
+	p.TypeAheadTimeout = time.Millisecond
+	time.Sleep(5 * time.Millisecond)
+	typeKey("C")
+	assert.Equal(t, "Cherry", p.Value)
+	assert.Equal(t, "c", p.TypeAhead)
+
+	time.Sleep(5 * time.Millisecond)
+	p.PressKey(&core.Key{Name: core.HomeKey})
+	typeKey("b")
+	assert.Equal(t, "Banana", p.Value)
+	p.TypeAheadTimeout = core.DefaultTypeAheadTimeout
+	typeKey("b")
+	assert.Equal(t, "Blueberry", p.Value)
+	typeKey("b")
+	assert.Equal(t, "Blackberry", p.Value)
+	typeKey("b")
+	assert.Equal(t, "Banana", p.Value)
+	typeKey("x")
+	assert.Equal(t, "Banana", p.Value)
+}
@@ -1,5 +1,7 @@
 package core
 
+import "maps"
+
 // Action represents an action that can be performed in the application.
 type Action int
 
@@ -13,8 +15,84 @@ const (
 	SpaceAction
 	SubmitAction
 	CancelAction
+	// LineStartAction moves the cursor of a text input to the start of the line
+	LineStartAction
+	// LineEndAction moves the cursor of a text input to the end of the line
+	LineEndAction
+	// WordLeftAction moves the cursor of a text input to the start of the previous word
+	WordLeftAction
+	// WordRightAction moves the cursor of a text input to the end of the next word
+	WordRightAction
+	// DeleteForwardAction deletes the character under the cursor of a text input
+	DeleteForwardAction
+	// DeleteWordAction kills the whitespace delimited word before the cursor of a text input
+	DeleteWordAction
+	// KillLineStartAction kills the text from the start of the line up to the cursor of a text input
+	KillLineStartAction
+	// KillLineEndAction kills the text from the cursor up to the end of the line of a text input
+	KillLineEndAction
+	// YankAction inserts the last killed text at the cursor of a text input
+	YankAction
+	// UndoAction reverts the last edit of a text input
+	UndoAction
+	// RedoAction restores the last undone edit of a text input
+	RedoAction
+	// HistorySearchAction starts a reverse incremental search in the history of a text input, or finds an older match
+	HistorySearchAction
+	// HistorySearchAbortAction aborts the history search of a text input, restoring the value it had before the search
+	HistorySearchAbortAction
+	// HalfPageUpAction moves the cursor of a list prompt up by half of the rendered options
+	HalfPageUpAction
+	// HalfPageDownAction moves the cursor of a list prompt down by half of the rendered options
+	HalfPageDownAction
+	// PageUpAction moves the cursor of a list prompt up by the number of rendered options
+	PageUpAction
+	// PageDownAction moves the cursor of a list prompt down by the number of rendered options
+	PageDownAction
 )
 
+// Keymap binds keys to actions of prompts which are not typed into, e.g. confirm and list prompts.
+// Its bindings take precedence over the aliases, so printable keys can be bound without preventing them to be typed in text inputs.
+// It does not apply while the options of a list prompt are filtered, so the keys are typed and edited in the filter as in text inputs.
+type Keymap map[KeyName]Action
+
+// VimKeymap returns a keymap with the vim navigation keys:
+// j/k to move down/up, h/l to move left/right, g/G to jump to the first/last option (g is a single key press, not vim's gg)
+// Ctrl+D/Ctrl+U to move down/up by half a page and Ctrl+F/Ctrl+B to move down/up by a page.
+func VimKeymap() Keymap {
+	return Keymap{
+		"j":      DownAction,
+		"k":      UpAction,
+		"h":      LeftAction,
+		"l":      RightAction,
+		"g":      HomeAction,
+		"G":      EndAction,
+		"Ctrl+d": HalfPageDownAction,
+		"Ctrl+u": HalfPageUpAction,
+		"Ctrl+f": PageDownAction,
+		"Ctrl+b": PageUpAction,
+	}
+}
+
+// EmacsKeymap returns a keymap with the emacs navigation keys:
+// Ctrl+N/Ctrl+P to move down/up, Ctrl+F/Ctrl+B to move right/left, Ctrl+V/Alt+V to move down/up by a page
+// and Alt+</Alt+> to jump to the first/last option.
+// As the kitty keyboard protocol reports the unshifted key, Alt+Shift+,/Alt+Shift+. are bound as well.
+func EmacsKeymap() Keymap {
+	return Keymap{
+		"Ctrl+n":      DownAction,
+		"Ctrl+p":      UpAction,
+		"Ctrl+f":      RightAction,
+		"Ctrl+b":      LeftAction,
+		"Alt+<":       HomeAction,
+		"Alt+>":       EndAction,
+		"Alt+Shift+,": HomeAction,
+		"Alt+Shift+.": EndAction,
+		"Ctrl+v":      PageDownAction,
+		"Alt+v":       PageUpAction,
+	}
+}
+
 // Custom messages for prompts
 type SettingsMessages struct {
 	// Custom message to display when a spinner is cancelled (default: "Canceled").
@@ -26,41 +104,85 @@ type SettingsMessages struct {
 // SettingsOptions defines user-configurable Settings for the application.
 type SettingsOptions struct {
 	// Aliases are custom key bindings for actions.
-	// If a key binding already exists in the aliases map, it is not overwritten.
+	// Modified keys are bound by their Key.Binding, e.g. "Ctrl+Right", "Alt+b" or "Shift+Tab".
 	Aliases map[KeyName]Action
+	// Keymap holds the key bindings of prompts which are not typed into, e.g. VimKeymap or EmacsKeymap (default: nil).
+	// They apply to confirm and list prompts, taking precedence over the aliases, except while a filter is typed.
+	Keymap Keymap
 	// Messages contains custom messages for the application.
 	Messages SettingsMessages
+	// KittyKeyboard enables the kitty keyboard protocol while prompts run, making chords as Ctrl+Enter or Ctrl+I bindable.
+	// Terminals without support for the protocol keep working with legacy key sequences.
+	KittyKeyboard bool
+	// Mouse enables mouse support in list prompts, to click options and scroll with the wheel.
+	Mouse bool
 }
 
-var Settings = SettingsOptions{
-	// aliases is a map that associates KeyName values with their corresponding Action.
-	// It defines default key bindings for actions in the application.
-	// Within any new alias coming from the user's land
-	Aliases: map[KeyName]Action{
-		UpKey:     UpAction,
-		DownKey:   DownAction,
-		LeftKey:   LeftAction,
-		RightKey:  RightAction,
-		HomeKey:   HomeAction,
-		EndKey:    EndAction,
-		SpaceKey:  SpaceAction,
-		EnterKey:  SubmitAction,
-		CancelKey: CancelAction,
-		EscapeKey: CancelAction,
-	},
-	// Messages contains default messages for the application.
-	Messages: SettingsMessages{
-		CancelMessage: "Canceled",
-		ErrorMessage:  "Something went wrong",
-	},
+// Settings are the global settings, used by prompts without their own Settings.
+var Settings = NewSettings()
+
+// NewSettings returns the default settings, with their own aliases map.
+// They can be changed and passed to prompts through their Settings param without affecting the global Settings,
+// e.g. to vary the key bindings between the sessions of a server.
+// Settings must not be changed while a prompt using them runs.
+//
+// Returns:
+//   - SettingsOptions: A new instance of the default SettingsOptions.
+func NewSettings() SettingsOptions {
+	return SettingsOptions{
+		// Aliases is a map that associates KeyName values with their corresponding Action.
+		// It defines default key bindings for actions in the application.
+		// Within any new alias coming from the user's land
+		Aliases: map[KeyName]Action{
+			UpKey:       UpAction,
+			DownKey:     DownAction,
+			LeftKey:     LeftAction,
+			RightKey:    RightAction,
+			HomeKey:     HomeAction,
+			EndKey:      EndAction,
+			SpaceKey:    SpaceAction,
+			PageUpKey:   PageUpAction,
+			PageDownKey: PageDownAction,
+			EnterKey:    SubmitAction,
+			CancelKey:   CancelAction,
+			EscapeKey:   CancelAction,
+			// Readline editing keys of text inputs
+			"Ctrl+a":  LineStartAction,
+			"Ctrl+e":  LineEndAction,
+			"Alt+b":   WordLeftAction,
+			"Alt+f":   WordRightAction,
+			DeleteKey: DeleteForwardAction,
+			"Ctrl+w":  DeleteWordAction,
+			"Ctrl+u":  KillLineStartAction,
+			"Ctrl+k":  KillLineEndAction,
+			"Ctrl+y":  YankAction,
+			// Undo and redo of text inputs, Ctrl+Shift+Z is only reported by terminals supporting the kitty keyboard protocol,
+			// while legacy terminals send it as Ctrl+Z, so redo is also bound to Alt+Z
+			"Ctrl+z":       UndoAction,
+			"Ctrl+Shift+z": RedoAction,
+			"Alt+z":        RedoAction,
+			"Ctrl+r":       HistorySearchAction,
+			"Ctrl+g":       HistorySearchAbortAction,
+		},
+		// Messages contains default messages for the application.
+		Messages: SettingsMessages{
+			CancelMessage: "Canceled",
+			ErrorMessage:  "Something went wrong",
+		},
+	}
 }
 
 // UpdateSettings updates the global SettingsOptions for the application.
+// The given aliases overwrite the existing ones, use Settings.Unbind to remove bindings.
+// As an unset bool can not be told apart from false, KittyKeyboard and Mouse are only ever switched on;
+// set Settings.KittyKeyboard or Settings.Mouse to switch them off, or pass a Clone of the settings to the prompts.
 func UpdateSettings(updates SettingsOptions) {
 	for alias, action := range updates.Aliases {
-		if _, exists := Settings.Aliases[alias]; !exists {
-			Settings.Aliases[alias] = action
-		}
+		Settings.Bind(action, alias)
+	}
+
+	if updates.Keymap != nil {
+		Settings.Keymap = updates.Keymap
 	}
 
 	if updates.Messages.CancelMessage != "" {
@@ -69,6 +191,74 @@ func UpdateSettings(updates SettingsOptions) {
 	if updates.Messages.ErrorMessage != "" {
 		Settings.Messages.ErrorMessage = updates.Messages.ErrorMessage
 	}
+	if updates.KittyKeyboard {
+		Settings.KittyKeyboard = true
+	}
+	if updates.Mouse {
+		Settings.Mouse = true
+	}
+}
+
+// Clone returns a copy of the settings with their own aliases and keymap, e.g. to derive the settings of a prompt from the global Settings.
+func (s SettingsOptions) Clone() SettingsOptions {
+	s.Aliases = maps.Clone(s.Aliases)
+	s.Keymap = maps.Clone(s.Keymap)
+	if s.Aliases == nil {
+		s.Aliases = make(map[KeyName]Action)
+	}
+	return s
+}
+
+// Bind binds keys to an action, overwriting their existing bindings.
+//
+// Parameters:
+//   - action (Action): The action to bind.
+//   - bindings (...KeyName): The keys to bind, by their Key.Binding, e.g. "Ctrl+n" or "j".
+func (s *SettingsOptions) Bind(action Action, bindings ...KeyName) {
+	if s.Aliases == nil {
+		s.Aliases = make(map[KeyName]Action)
+	}
+	for _, binding := range bindings {
+		s.Aliases[binding] = action
+	}
+}
+
+// Unbind removes the bindings of keys, which then trigger no action.
+//
+// Parameters:
+//   - bindings (...KeyName): The keys to unbind, by their Key.Binding.
+func (s *SettingsOptions) Unbind(bindings ...KeyName) {
+	for _, binding := range bindings {
+		delete(s.Aliases, binding)
+	}
+}
+
+// resolveSettings returns the given settings, or the global Settings if they are nil.
+func resolveSettings(settings *SettingsOptions) *SettingsOptions {
+	if settings == nil {
+		return &Settings
+	}
+	return settings
+}
+
+// lookupAction returns the action bound to a key in the aliases map of the settings.
+// The key is looked up by its binding first, e.g. "Ctrl+Right", and named keys fall back to their plain name,
+// so modified keys keep the action of the unmodified key unless they are explicitly bound.
+//
+// Parameters:
+//   - key (*Key): The key to look up.
+//
+// Returns:
+//   - action (Action): The action bound to the key.
+//   - exists (bool): Whether an action is bound to the key.
+func (s *SettingsOptions) lookupAction(key *Key) (action Action, exists bool) {
+	if action, exists = s.Aliases[key.Binding()]; exists {
+		return action, true
+	}
+	if len(key.Name) > 1 {
+		action, exists = s.Aliases[key.Name]
+	}
+	return action, exists
 }
 
 // NewActionHandler creates a closure that handles key events and maps them to actions.
@@ -82,8 +272,13 @@ func UpdateSettings(updates SettingsOptions) {
 // Returns:
 //   - actionHandler (func(key *Key)): A action handler that handles key events and invokes the appropriate listener.
 func NewActionHandler(listeners map[Action]func(), defaultListener func(key *Key)) (actionHandler func(key *Key)) {
+	return newActionHandler(func(key *Key) (Action, bool) { return Settings.lookupAction(key) }, listeners, defaultListener)
+}
+
+// newActionHandler creates an action handler looking up the action of each key with lookupAction.
+func newActionHandler(lookupAction func(key *Key) (Action, bool), listeners map[Action]func(), defaultListener func(key *Key)) func(key *Key) {
 	return func(key *Key) {
-		if action, actionExists := Settings.Aliases[key.Name]; actionExists {
+		if action, actionExists := lookupAction(key); actionExists {
 			if listener, listenerExists := listeners[action]; listenerExists {
 				if listener != nil {
 					listener()
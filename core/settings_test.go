@@ -1,6 +1,7 @@
 package core_test
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/orochaa/go-clack/core"
@@ -94,15 +95,148 @@ func TestTriggerIgnoredActionOverDefaultAction(t *testing.T) {
 }
 
 func TestTriggerActionWithInternalKeyAlias(t *testing.T) {
+	defer func() { core.Settings = core.NewSettings() }()
 	core.UpdateSettings(core.SettingsOptions{
 		Aliases: map[core.KeyName]core.Action{
 			core.UpKey: core.DownAction,
 		},
 	})
+	counter := 0
 
 	actionHandler := core.NewActionHandler(map[core.Action]func(){
-		core.DownAction: t.FailNow,
+		core.UpAction:   t.FailNow,
+		core.DownAction: func() { counter++ },
 	}, nil)
 	actionHandler(&core.Key{Name: core.UpKey})
 
+	assert.Equal(t, 1, counter)
+}
+
+func TestSettingsBindAndUnbind(t *testing.T) {
+	settings := core.NewSettings()
+	settings.Bind(core.DownAction, core.UpKey, "j")
+	settings.Unbind(core.DownKey)
+
+	assert.Equal(t, core.DownAction, settings.Aliases[core.UpKey])
+	assert.Equal(t, core.DownAction, settings.Aliases["j"])
+	assert.NotContains(t, settings.Aliases, core.DownKey)
+	assert.Equal(t, core.UpAction, core.Settings.Aliases[core.UpKey])
+	assert.Contains(t, core.Settings.Aliases, core.DownKey)
+}
+
+func TestSettingsClone(t *testing.T) {
+	settings := core.Settings.Clone()
+	settings.Bind(core.UpAction, "Ctrl+p")
+
+	assert.Equal(t, core.UpAction, settings.Aliases["Ctrl+p"])
+	assert.NotContains(t, core.Settings.Aliases, core.KeyName("Ctrl+p"))
+}
+
+func TestPromptSettings(t *testing.T) {
+	newSelect := func(settings *core.SettingsOptions) *core.SelectPrompt[string] {
+		return core.NewSelectPrompt(core.SelectPromptParams[string]{
+			Options: []*core.SelectOption[string]{
+				{Label: "a"},
+				{Label: "b"},
+				{Label: "c"},
+			},
+			Settings: settings,
+			Render:   func(p *core.SelectPrompt[string]) string { return "" },
+		})
+	}
+
+	emacs := core.NewSettings()
+	emacs.Bind(core.DownAction, "Ctrl+n")
+	emacs.Unbind(core.DownKey)
+	emacsPrompt := newSelect(&emacs)
+	defaultPrompt := newSelect(nil)
+
+	emacsPrompt.PressKey(&core.Key{Name: "n", Ctrl: true})
+	emacsPrompt.PressKey(&core.Key{Name: core.DownKey})
+	assert.Equal(t, 1, emacsPrompt.CursorIndex)
+
+	defaultPrompt.PressKey(&core.Key{Name: "n", Ctrl: true})
+	defaultPrompt.PressKey(&core.Key{Name: core.DownKey})
+	assert.Equal(t, 1, defaultPrompt.CursorIndex)
+	assert.NotContains(t, core.Settings.Aliases, core.KeyName("Ctrl+n"))
+
+	emacs.Unbind(core.EnterKey)
+	emacsPrompt.PressKey(&core.Key{Name: core.EnterKey})
+	assert.Equal(t, core.ActiveState, emacsPrompt.State)
+	defaultPrompt.PressKey(&core.Key{Name: core.EnterKey})
+	assert.Equal(t, core.SubmitState, defaultPrompt.State)
+}
+
+func TestVimKeymap(t *testing.T) {
+	settings := core.NewSettings()
+	settings.Keymap = core.VimKeymap()
+	newSelect := func(filter bool) *core.SelectPrompt[string] {
+		options := make([]*core.SelectOption[string], 30)
+		for i := range options {
+			options[i] = &core.SelectOption[string]{Label: fmt.Sprintf("option %d", i)}
+		}
+		return core.NewSelectPrompt(core.SelectPromptParams[string]{
+			Options:  options,
+			Filter:   filter,
+			Settings: &settings,
+			Render:   func(p *core.SelectPrompt[string]) string { return "" },
+		})
+	}
+
+	p := newSelect(false)
+	p.PressKey(&core.Key{Name: "j", Char: "j"})
+	assert.Equal(t, 1, p.CursorIndex)
+	p.PressKey(&core.Key{Name: "k", Char: "k"})
+	assert.Equal(t, 0, p.CursorIndex)
+	p.PressKey(&core.Key{Name: "G", Char: "G"})
+	assert.Equal(t, 29, p.CursorIndex)
+	p.PressKey(&core.Key{Name: "g", Char: "g"})
+	assert.Equal(t, 0, p.CursorIndex)
+	p.PressKey(&core.Key{Name: "d", Ctrl: true})
+	assert.Equal(t, 5, p.CursorIndex)
+	p.PressKey(&core.Key{Name: "u", Ctrl: true})
+	p.PressKey(&core.Key{Name: "u", Ctrl: true})
+	assert.Equal(t, 0, p.CursorIndex)
+	assert.Equal(t, "option 0", p.Value)
+
+	p = newSelect(true)
+	p.PressKey(&core.Key{Name: "j", Char: "j"})
+	p.PressKey(&core.Key{Name: "G", Char: "G"})
+	assert.Equal(t, "jG", p.Search)
+	p.PressKey(&core.Key{Name: "u", Ctrl: true})
+	assert.Equal(t, "", p.Search)
+	assert.Equal(t, 0, p.CursorIndex)
+}
+
+func TestEmacsKeymap(t *testing.T) {
+	settings := core.NewSettings()
+	settings.Keymap = core.EmacsKeymap()
+
+	p := core.NewConfirmPrompt(core.ConfirmPromptParams{
+		Settings: &settings,
+		Render:   func(p *core.ConfirmPrompt) string { return "" },
+	})
+	p.PressKey(&core.Key{Name: "n", Ctrl: true})
+	assert.True(t, p.Value)
+
+	text := core.NewTextPrompt(core.TextPromptParams{
+		Settings: &settings,
+		Render:   func(p *core.TextPrompt) string { return "" },
+	})
+	text.PressKey(&core.Key{Name: "a", Char: "a"})
+	text.PressKey(&core.Key{Name: "b", Char: "b"})
+	text.PressKey(&core.Key{Name: "b", Ctrl: true})
+	text.PressKey(&core.Key{Name: "x", Char: "x"})
+	assert.Equal(t, "abx", text.Value)
+
+	// Alt+> and Alt+< as reported by the kitty keyboard protocol
+	selectPrompt := core.NewSelectPrompt(core.SelectPromptParams[string]{
+		Settings: &settings,
+		Options:  []*core.SelectOption[string]{{Label: "foo"}, {Label: "bar"}, {Label: "baz"}},
+		Render:   func(p *core.SelectPrompt[string]) string { return "" },
+	})
+	selectPrompt.PressKey(&core.Key{Name: ".", Alt: true, Shift: true})
+	assert.Equal(t, 2, selectPrompt.CursorIndex)
+	selectPrompt.PressKey(&core.Key{Name: ",", Alt: true, Shift: true})
+	assert.Equal(t, 0, selectPrompt.CursorIndex)
 }
@@ -2,8 +2,11 @@ package core
 
 import (
 	"context"
-	"os"
+	"io"
+	"strings"
+	"time"
 
+	"github.com/orochaa/go-clack/core/utils"
 	"github.com/orochaa/go-clack/core/validator"
 	"github.com/orochaa/go-clack/third_party/picocolors"
 )
@@ -12,17 +15,35 @@ type TextPrompt struct {
 	Prompt[string]
 	Placeholder string
 	Required    bool
+
+	History            HistoryStore
+	HistoryID          string
+	HistorySearch      string
+	IsSearchingHistory bool
+	historyEntries     []string
+	historyIndex       int
+	historyDraft       string
 }
 
 type TextPromptParams struct {
-	Context      context.Context
-	Input        *os.File
-	Output       *os.File
-	InitialValue string
-	Placeholder  string
-	Required     bool
-	Validate     func(value string) error
-	Render       func(p *TextPrompt) string
+	Context           context.Context
+	Input             io.Reader
+	Output            io.Writer
+	InitialValue      string
+	Placeholder       string
+	Required          bool
+	Validate          func(value string) error
+	LiveValidate      func(ctx context.Context, value string) error
+	LiveValidateDelay time.Duration
+	History           HistoryStore
+	HistoryID         string
+	Timeout           time.Duration
+	TimeoutCancel     bool
+	Ephemeral         bool
+	LineMode          bool
+	Summary           func(value string) string
+	Settings          *SettingsOptions
+	Render            func(p *TextPrompt) string
 }
 
 // NewTextPrompt initializes and returns a new instance of TextPrompt.
@@ -34,12 +55,22 @@ type TextPromptParams struct {
 //
 // Parameters:
 //   - Context (context.Context): The context for the prompt (default: context.Background).
-//   - Input (*os.File): The input stream for the prompt (default: OSFileSystem).
-//   - Output (*os.File): The output stream for the prompt (default: OSFileSystem).
+//   - Input (io.Reader): The input stream for the prompt (default: os.Stdin).
+//   - Output (io.Writer): The output stream for the prompt (default: os.Stdout).
 //   - InitialValue (string): The initial value of the text input (default: "").
 //   - Placeholder (string): The placeholder text to display when the input is empty (default: "").
 //   - Required (bool): Whether the text input is required (default: false).
 //   - Validate (func(value string) error): Custom validation function for the input (default: nil).
+//   - LiveValidate (func(ctx context.Context, value string) error): Validation function run as the user types, whose context is cancelled once the value changes (default: nil).
+//   - LiveValidateDelay (time.Duration): The time waited after the last keystroke before validating live (default: DefaultLiveValidateDelay).
+//   - History (HistoryStore): The store of previous answers, recalled with Up/Down and searched with Ctrl+R (default: nil).
+//   - HistoryID (string): The ID of the prompt in the history store, shared by prompts asking the same question (default: "").
+//   - Timeout (time.Duration): The time after which the current value is submitted, unless the user presses a key before (default: 0, no timeout).
+//   - TimeoutCancel (bool): Whether to cancel the prompt with ErrTimeout instead of submitting the current value on timeout (default: false).
+//   - Ephemeral (bool): Whether to erase the prompt once submitted, or replace it by its Summary (default: false).
+//   - LineMode (bool): Whether to read whole lines as answers, without raw mode or cursor movements, as done when the input is not a terminal (default: false).
+//   - Summary (func(value string) string): The one-line summary of the submitted value of an ephemeral prompt (default: nil, the prompt is erased).
+//   - Settings (*SettingsOptions): The key bindings and options of the prompt (default: the global Settings).
 //   - Render (func(p *TextPrompt) string): Custom render function for the prompt (default: nil).
 //
 // Returns:
@@ -51,27 +82,49 @@ func NewTextPrompt(params TextPromptParams) *TextPrompt {
 	var p TextPrompt
 	p = TextPrompt{
 		Prompt: *NewPrompt(PromptParams[string]{
-			Context:      params.Context,
-			Input:        params.Input,
-			Output:       params.Output,
-			InitialValue: params.InitialValue,
-			CursorIndex:  len(params.InitialValue),
-			Validate:     WrapValidate(params.Validate, &p.Required, "Value is required! Please enter a value."),
-			Render:       WrapRender[string](&p, params.Render),
+			Context:           params.Context,
+			Input:             params.Input,
+			Output:            params.Output,
+			InitialValue:      params.InitialValue,
+			CursorIndex:       utils.GraphemeCount(params.InitialValue),
+			Validate:          WrapValidate(params.Validate, &p.Required, "Value is required! Please enter a value."),
+			LiveValidate:      params.LiveValidate,
+			LiveValidateDelay: params.LiveValidateDelay,
+			ParseLine:         p.parseLine,
+			Timeout:           params.Timeout,
+			TimeoutCancel:     params.TimeoutCancel,
+			Ephemeral:         params.Ephemeral,
+			LineMode:          params.LineMode,
+			Summary:           params.Summary,
+			Settings:          params.Settings,
+			Render:            WrapRender[string](&p, params.Render),
 		}),
 		Placeholder: params.Placeholder,
 		Required:    params.Required,
+		History:     params.History,
+		HistoryID:   params.HistoryID,
 	}
+	p.loadHistory()
 
-	p.On(KeyEvent, func(args ...any) {
-		key := args[0].(*Key)
+	actionHandler := p.NewActionHandler(map[Action]func(){
+		UpAction:            func() { p.recallHistory(-1) },
+		DownAction:          func() { p.recallHistory(1) },
+		HistorySearchAction: p.startHistorySearch,
+	}, p.handleKeyPress)
+	p.OnKey(func(key *Key) {
+		if p.IsSearchingHistory && p.handleHistorySearch(key) {
+			return
+		}
 
 		if key.Name == EnterKey && p.Value == "" && p.Placeholder != "" {
 			p.Value = p.Placeholder
 			return
 		}
 
-		p.handleKeyPress(key)
+		actionHandler(key)
+	})
+	p.OnSubmit(func(value string) {
+		p.saveHistory()
 	})
 
 	return &p
@@ -84,15 +137,34 @@ func NewTextPrompt(params TextPromptParams) *TextPrompt {
 // Parameters:
 //   - key (*Key): The key event to process.
 func (p *TextPrompt) handleKeyPress(key *Key) {
-	if key.Name == TabKey && p.Value == "" && p.Placeholder != "" {
+	if key.Binding() == TabKey && p.Value == "" && p.Placeholder != "" {
 		p.Value = p.Placeholder
-		p.CursorIndex = len(p.Placeholder)
+		p.CursorIndex = utils.GraphemeCount(p.Placeholder)
 		return
 	}
 
 	p.Value, p.CursorIndex = p.TrackKeyValue(key, p.Value, p.CursorIndex)
 }
 
+// parseLine parses a line answer into the input value.
+// An empty answer keeps the current value, falling back to the placeholder.
+//
+// Parameters:
+//   - line (string): The line answer to parse.
+//
+// Returns:
+//   - string: The parsed value.
+//   - error: Always nil.
+func (p *TextPrompt) parseLine(line string) (string, error) {
+	if line == "" && p.Value == "" {
+		return p.Placeholder, nil
+	}
+	if line == "" {
+		return p.Value, nil
+	}
+	return line, nil
+}
+
 // ValueWithCursor returns the current input value with a cursor indicator.
 // The cursor is represented by an inverse character at the current cursor position.
 // If the cursor is at the end of the value, it is displayed as a block character.
@@ -100,8 +172,109 @@ func (p *TextPrompt) handleKeyPress(key *Key) {
 // Returns:
 //   - string: The input value with the cursor indicator.
 func (p *TextPrompt) ValueWithCursor() string {
-	if p.CursorIndex == len(p.Value) {
+	clusters := utils.Graphemes(p.Value)
+	if p.CursorIndex >= len(clusters) {
 		return p.Value + "█"
 	}
-	return p.Value[0:p.CursorIndex] + picocolors.Inverse(string(p.Value[p.CursorIndex])) + p.Value[p.CursorIndex+1:]
+	return strings.Join(clusters[:p.CursorIndex], "") + picocolors.Inverse(clusters[p.CursorIndex]) + strings.Join(clusters[p.CursorIndex+1:], "")
+}
+
+// loadHistory loads the previous answers from the history store.
+// The history is a convenience, so a store failing to load is treated as an empty history.
+func (p *TextPrompt) loadHistory() {
+	if p.History != nil {
+		p.historyEntries, _ = p.History.Load(p.HistoryID)
+	}
+	p.historyIndex = len(p.historyEntries)
+}
+
+// saveHistory adds the submitted value to the history store, unless it is empty.
+func (p *TextPrompt) saveHistory() {
+	if p.History == nil || p.Value == "" {
+		return
+	}
+	if err := p.History.Add(p.HistoryID, p.Value); err == nil {
+		p.loadHistory()
+	}
+}
+
+// recallHistory replaces the value with an older or newer answer from the history.
+// The value being typed is kept as a draft, and restored once the newest answer is passed.
+//
+// Parameters:
+//   - direction (int): The direction to move in the history (-1 for older, 1 for newer).
+func (p *TextPrompt) recallHistory(direction int) {
+	index := p.historyIndex + direction
+	if index < 0 || index > len(p.historyEntries) {
+		return
+	}
+	if p.historyIndex == len(p.historyEntries) {
+		p.historyDraft = p.Value
+	}
+
+	p.historyIndex = index
+	if index == len(p.historyEntries) {
+		p.Value = p.historyDraft
+	} else {
+		p.Value = p.historyEntries[index]
+	}
+	p.CursorIndex = utils.GraphemeCount(p.Value)
+}
+
+// startHistorySearch starts a reverse incremental search in the history.
+func (p *TextPrompt) startHistorySearch() {
+	if len(p.historyEntries) == 0 {
+		return
+	}
+	p.IsSearchingHistory = true
+	p.HistorySearch = ""
+	p.historyDraft = p.Value
+	p.historyIndex = len(p.historyEntries)
+}
+
+// handleHistorySearch processes key events while searching the history.
+// Typed text refines the search, Ctrl+R finds an older match and Ctrl+G aborts the search, restoring the previous value.
+// Any other key accepts the match and is processed as usual, so Enter submits it.
+//
+// Parameters:
+//   - key (*Key): The key event to process.
+//
+// Returns:
+//   - bool: Whether the key was consumed by the search.
+func (p *TextPrompt) handleHistorySearch(key *Key) bool {
+	action, actionExists := p.lookupAction(key)
+	switch {
+	case actionExists && action == HistorySearchAction:
+		p.findHistory(p.historyIndex - 1)
+		return true
+	case actionExists && action == HistorySearchAbortAction:
+		p.IsSearchingHistory = false
+		p.historyIndex = len(p.historyEntries)
+		p.Value = p.historyDraft
+		p.CursorIndex = utils.GraphemeCount(p.Value)
+		return true
+	case key.Name == BackspaceKey || key.Name == SpaceKey || key.Name == PasteKey || (key.Char != "" && !key.Ctrl && !key.Alt):
+		p.HistorySearch, _ = p.trackKeyValue(key, p.HistorySearch, utils.GraphemeCount(p.HistorySearch))
+		p.findHistory(len(p.historyEntries) - 1)
+		return true
+	}
+
+	p.IsSearchingHistory = false
+	return false
+}
+
+// findHistory shows the newest answer containing the search, starting from the given index of the history.
+// If no answer matches, the current match is kept.
+func (p *TextPrompt) findHistory(from int) {
+	if p.HistorySearch == "" {
+		return
+	}
+	for i := min(from, len(p.historyEntries)-1); i >= 0; i-- {
+		if strings.Contains(p.historyEntries[i], p.HistorySearch) {
+			p.historyIndex = i
+			p.Value = p.historyEntries[i]
+			p.CursorIndex = utils.GraphemeCount(p.Value)
+			return
+		}
+	}
 }
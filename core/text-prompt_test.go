@@ -1,7 +1,9 @@
 package core_test
 
 import (
+	"bytes"
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/orochaa/go-clack/core"
@@ -42,6 +44,136 @@ func TestTextPromptValueTrack(t *testing.T) {
 	assert.Equal(t, "b", p.Value)
 }
 
+func TestTextPromptPaste(t *testing.T) {
+	p := newTextPrompt()
+
+	p.PressKey(&core.Key{Char: "a"})
+	p.PressKey(&core.Key{Name: core.PasteKey, Char: "foo\nbar"})
+	assert.Equal(t, "afoo bar", p.Value)
+	assert.Equal(t, 8, p.CursorIndex)
+	assert.Equal(t, core.ActiveState, p.State)
+}
+
+func TestTextPromptEditingKeys(t *testing.T) {
+	p := core.NewTextPrompt(core.TextPromptParams{
+		// Type "foo bar", kill "bar" with Ctrl+W, go to the start with Ctrl+A and yank it back with Ctrl+Y
+		Input:  strings.NewReader("foo bar\x17\x01\x19\r"),
+		Output: &bytes.Buffer{},
+		Render: func(p *core.TextPrompt) string { return "" },
+	})
+
+	value, err := p.Run()
+	assert.NoError(t, err)
+	assert.Equal(t, "barfoo ", value)
+}
+
+func newHistoryTextPrompt(entries ...string) *core.TextPrompt {
+	history := core.NewMemoryHistory(0)
+	for _, entry := range entries {
+		history.Add("foo", entry)
+	}
+	return core.NewTextPrompt(core.TextPromptParams{
+		History:   history,
+		HistoryID: "foo",
+		Render:    func(p *core.TextPrompt) string { return "" },
+	})
+}
+
+func TestTextPromptHistoryRecall(t *testing.T) {
+	p := newHistoryTextPrompt("a", "b")
+
+	p.PressKey(&core.Key{Char: "x"})
+	p.PressKey(&core.Key{Name: core.UpKey})
+	assert.Equal(t, "b", p.Value)
+	assert.Equal(t, 1, p.CursorIndex)
+	p.PressKey(&core.Key{Name: core.UpKey})
+	assert.Equal(t, "a", p.Value)
+	p.PressKey(&core.Key{Name: core.UpKey})
+	assert.Equal(t, "a", p.Value)
+
+	p.PressKey(&core.Key{Name: core.DownKey})
+	assert.Equal(t, "b", p.Value)
+	p.PressKey(&core.Key{Name: core.DownKey})
+	assert.Equal(t, "x", p.Value)
+	p.PressKey(&core.Key{Name: core.DownKey})
+	assert.Equal(t, "x", p.Value)
+}
+
+func TestTextPromptHistorySave(t *testing.T) {
+	history := core.NewMemoryHistory(0)
+	run := func(input string) string {
+		p := core.NewTextPrompt(core.TextPromptParams{
+			Input:     strings.NewReader(input),
+			Output:    &bytes.Buffer{},
+			History:   history,
+			HistoryID: "foo",
+			Render:    func(p *core.TextPrompt) string { return "" },
+		})
+		value, err := p.Run()
+		assert.NoError(t, err)
+		return value
+	}
+
+	run("foo\r")
+	run("bar\r")
+	run("\r")
+	assert.Equal(t, "foo", run("\x1b[A\x1b[A\r"))
+
+	entries, _ := history.Load("foo")
+	assert.Equal(t, []string{"bar", "foo"}, entries)
+}
+
+func TestTextPromptHistorySearch(t *testing.T) {
+	p := newHistoryTextPrompt("foo", "bar", "baz", "qux")
+
+	p.PressKey(&core.Key{Name: "r", Ctrl: true})
+	assert.True(t, p.IsSearchingHistory)
+	p.PressKey(&core.Key{Char: "b"})
+	assert.Equal(t, "b", p.HistorySearch)
+	assert.Equal(t, "baz", p.Value)
+	p.PressKey(&core.Key{Name: "r", Ctrl: true})
+	assert.Equal(t, "bar", p.Value)
+	p.PressKey(&core.Key{Name: "r", Ctrl: true})
+	assert.Equal(t, "bar", p.Value)
+	p.PressKey(&core.Key{Char: "x"})
+	assert.Equal(t, "bx", p.HistorySearch)
+	assert.Equal(t, "bar", p.Value)
+
+	p.PressKey(&core.Key{Name: "g", Ctrl: true})
+	assert.False(t, p.IsSearchingHistory)
+	assert.Equal(t, "", p.Value)
+
+	p.PressKey(&core.Key{Name: "r", Ctrl: true})
+	p.PressKey(&core.Key{Char: "o"})
+	assert.Equal(t, "foo", p.Value)
+	p.PressKey(&core.Key{Name: core.EnterKey})
+	assert.False(t, p.IsSearchingHistory)
+	assert.Equal(t, "foo", p.Value)
+	assert.Equal(t, core.SubmitState, p.State)
+}
+
+func TestTextPromptHistorySearchAbortBinding(t *testing.T) {
+	settings := core.Settings.Clone()
+	settings.Bind(core.HistorySearchAbortAction, core.EscapeKey)
+	history := core.NewMemoryHistory(0)
+	history.Add("foo", "bar")
+	p := core.NewTextPrompt(core.TextPromptParams{
+		InitialValue: "baz",
+		History:      history,
+		HistoryID:    "foo",
+		Settings:     &settings,
+		Render:       func(p *core.TextPrompt) string { return "" },
+	})
+
+	p.PressKey(&core.Key{Name: "r", Ctrl: true})
+	p.PressKey(&core.Key{Char: "b"})
+	assert.Equal(t, "bar", p.Value)
+	p.PressKey(&core.Key{Name: core.EscapeKey})
+	assert.False(t, p.IsSearchingHistory)
+	assert.Equal(t, "baz", p.Value)
+	assert.NotEqual(t, core.CancelState, p.State)
+}
+
 func TestTextPromptValueWithCursor(t *testing.T) {
 	p := newTextPrompt()
 	cursor := "█"
@@ -63,6 +195,22 @@ func TestTextPromptValueWithCursor(t *testing.T) {
 	assert.Equal(t, expected, p.ValueWithCursor())
 }
 
+func TestTextPromptValueWithCursorGraphemes(t *testing.T) {
+	p := core.NewTextPrompt(core.TextPromptParams{
+		InitialValue: "ça日👍🏽",
+		Render:       func(p *core.TextPrompt) string { return "" },
+	})
+
+	assert.Equal(t, 4, p.CursorIndex)
+	assert.Equal(t, "ça日👍🏽█", p.ValueWithCursor())
+
+	p.PressKey(&core.Key{Name: core.LeftKey})
+	assert.Equal(t, "ça日"+picocolors.Inverse("👍🏽"), p.ValueWithCursor())
+
+	p.PressKey(&core.Key{Name: core.HomeKey})
+	assert.Equal(t, picocolors.Inverse("ç")+"a日👍🏽", p.ValueWithCursor())
+}
+
 func TestTextPromptValidate(t *testing.T) {
 	p := core.NewTextPrompt(core.TextPromptParams{
 		InitialValue: "123",
@@ -100,3 +248,17 @@ func TestTextRequiredValue(t *testing.T) {
 	p.PressKey(&core.Key{Name: core.EnterKey})
 	assert.Equal(t, core.ErrorState, p.State)
 }
+
+func TestTextPromptLineMode(t *testing.T) {
+	p := core.NewTextPrompt(core.TextPromptParams{
+		Input:       strings.NewReader("\n"),
+		Output:      &bytes.Buffer{},
+		Placeholder: "foo",
+		Render:      func(p *core.TextPrompt) string { return "" },
+	})
+	p.LineMode = true
+
+	value, err := p.Run()
+	assert.NoError(t, err)
+	assert.Equal(t, "foo", value)
+}
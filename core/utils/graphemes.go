@@ -0,0 +1,69 @@
+package utils
+
+import "unicode"
+
+const zeroWidthJoiner = 0x200d
+
+// isRegionalIndicator reports whether a rune is a regional indicator, pairs of which form flag emojis.
+func isRegionalIndicator(r rune) bool {
+	return r >= 0x1f1e6 && r <= 0x1f1ff
+}
+
+// isGraphemeExtender reports whether a rune extends the previous grapheme cluster instead of starting a new one.
+// It covers combining marks, zero-width joiners, variation selectors, emoji skin tone modifiers and emoji tags.
+func isGraphemeExtender(r rune) bool {
+	return unicode.In(r, unicode.Mn, unicode.Me, unicode.Mc) ||
+		r == zeroWidthJoiner ||
+		(r >= 0xfe00 && r <= 0xfe0f) ||
+		(r >= 0xe0100 && r <= 0xe01ef) ||
+		(r >= 0x1f3fb && r <= 0x1f3ff) ||
+		(r >= 0xe0020 && r <= 0xe007f)
+}
+
+// isGraphemeBoundary reports whether a grapheme cluster ends between two runes.
+// regionalIndicators is the number of consecutive regional indicators in the current cluster, up to prev.
+func isGraphemeBoundary(prev rune, r rune, regionalIndicators int) bool {
+	switch {
+	case prev == '\r' && r == '\n':
+		return false
+	case isControlCharacter(prev) || isControlCharacter(r):
+		return true
+	case isGraphemeExtender(r), prev == zeroWidthJoiner:
+		return false
+	case isRegionalIndicator(prev) && isRegionalIndicator(r):
+		return regionalIndicators%2 == 0
+	}
+	return true
+}
+
+// Graphemes splits a string into its grapheme clusters, which are the characters perceived by the user.
+// A cluster is a base character followed by its combining marks and modifiers, an emoji sequence joined by zero-width joiners,
+// a flag made of two regional indicators or a "\r\n" line break.
+func Graphemes(str string) []string {
+	var clusters []string
+	start, prev, regionalIndicators := 0, rune(-1), 0
+
+	for i, r := range str {
+		if prev != -1 && isGraphemeBoundary(prev, r, regionalIndicators) {
+			clusters = append(clusters, str[start:i])
+			start = i
+			regionalIndicators = 0
+		}
+		if isRegionalIndicator(r) {
+			regionalIndicators++
+		} else {
+			regionalIndicators = 0
+		}
+		prev = r
+	}
+
+	if start < len(str) {
+		clusters = append(clusters, str[start:])
+	}
+	return clusters
+}
+
+// GraphemeCount returns the number of grapheme clusters in a string.
+func GraphemeCount(str string) int {
+	return len(Graphemes(str))
+}
@@ -4,52 +4,6 @@ func isControlCharacter(r rune) bool {
 	return r <= 0x1f || (r >= 0x7f && r <= 0x9f)
 }
 
-func isCombiningCharacter(r rune) bool {
-	return r >= 0x300 && r <= 0x36f
-}
-
-func isSurrogatePair(r rune) bool {
-	return r >= 0xd800 && r <= 0xdbff
-}
-
-func StrLength(str string) int {
-	if len(str) == 0 {
-		return 0
-	}
-
-	length := 0
-	inEscapeCode := false
-
-	for i := 0; i < len(str); i++ {
-		r := rune(str[i])
-
-		if inEscapeCode {
-			if r == 'm' {
-				inEscapeCode = false
-			}
-			continue
-		}
-
-		if r == '\x1b' {
-			inEscapeCode = true
-			// length++ // count the escape code as 1 character
-			continue
-		}
-
-		if isControlCharacter(r) || isCombiningCharacter(r) {
-			continue
-		}
-
-		if isSurrogatePair(r) {
-			i++
-		}
-
-		length++
-	}
-
-	return length
-}
-
 func MinMaxIndex(index int, max int) int {
 	if index < 0 {
 		return max - 1
@@ -97,3 +51,18 @@ func SplitLines(str string) []string {
 
 	return lines
 }
+
+// CountRows counts the terminal rows taken by a string, once its lines are wrapped at the given width.
+// Empty lines take one row, and a width lower than 1 disables wrapping, counting one row per line.
+func CountRows(str string, width int) int {
+	rows := 0
+	for _, line := range SplitLines(str) {
+		length := StrLength(line)
+		if width < 1 || length <= width {
+			rows++
+			continue
+		}
+		rows += (length + width - 1) / width
+	}
+	return rows
+}
@@ -17,6 +17,72 @@ func TestStrLength(t *testing.T) {
 	assert.Equal(t, 1, utils.StrLength(picocolors.Green("◆")))
 	assert.Equal(t, 5, utils.StrLength(picocolors.Green("◇")+" "+"Foo"))
 	assert.Equal(t, 5, utils.StrLength(picocolors.Green("o")+" "+"Foo"))
+	assert.Equal(t, 4, utils.StrLength("日本"))
+	assert.Equal(t, 4, utils.StrLength(picocolors.Cyan("日本")))
+	assert.Equal(t, 4, utils.StrLength("ｆｏ"))
+	assert.Equal(t, 1, utils.StrLength("e\u0301"))
+	assert.Equal(t, 2, utils.StrLength("👍🏽"))
+	assert.Equal(t, 2, utils.StrLength("👩\u200d💻"))
+	assert.Equal(t, 2, utils.StrLength("🇫🇷"))
+	assert.Equal(t, 2, utils.StrLength("❤\ufe0f"))
+	assert.Equal(t, 1, utils.StrLength("❤"))
+	assert.Equal(t, 0, utils.StrLength("\u200b"))
+}
+
+func TestRuneWidth(t *testing.T) {
+	assert.Equal(t, 1, utils.RuneWidth('a'))
+	assert.Equal(t, 2, utils.RuneWidth('日'))
+	assert.Equal(t, 2, utils.RuneWidth('한'))
+	assert.Equal(t, 2, utils.RuneWidth('🚀'))
+	assert.Equal(t, 0, utils.RuneWidth('\u0301'))
+	assert.Equal(t, 0, utils.RuneWidth('\x1b'))
+}
+
+func TestSplitAtWidth(t *testing.T) {
+	head, tail := utils.SplitAtWidth("foobar", 3)
+	assert.Equal(t, "foo", head)
+	assert.Equal(t, "bar", tail)
+
+	head, tail = utils.SplitAtWidth("日本語", 3)
+	assert.Equal(t, "日", head)
+	assert.Equal(t, "本語", tail)
+
+	head, tail = utils.SplitAtWidth("日本語", 1)
+	assert.Equal(t, "日", head)
+	assert.Equal(t, "本語", tail)
+
+	head, tail = utils.SplitAtWidth("e\u0301te\u0301", 2)
+	assert.Equal(t, "e\u0301t", head)
+	assert.Equal(t, "e\u0301", tail)
+
+	head, tail = utils.SplitAtWidth(picocolors.Cyan("foo")+"bar", 4)
+	assert.Equal(t, 4, utils.StrLength(head))
+	assert.Equal(t, "ar", tail)
+}
+
+func TestGraphemes(t *testing.T) {
+	assert.Equal(t, []string(nil), utils.Graphemes(""))
+	assert.Equal(t, []string{"f", "o", "o"}, utils.Graphemes("foo"))
+	assert.Equal(t, []string{"ç", "a"}, utils.Graphemes("ça"))
+	assert.Equal(t, []string{"e\u0301", "t", "e\u0301"}, utils.Graphemes("e\u0301te\u0301"))
+	assert.Equal(t, []string{"日", "本"}, utils.Graphemes("日本"))
+	assert.Equal(t, []string{"👍🏽", "!"}, utils.Graphemes("👍🏽!"))
+	assert.Equal(t, []string{"👩\u200d💻", "a"}, utils.Graphemes("👩\u200d💻a"))
+	assert.Equal(t, []string{"❤\ufe0f"}, utils.Graphemes("❤\ufe0f"))
+	assert.Equal(t, []string{"🇫🇷", "🇩🇪", "🇮"}, utils.Graphemes("🇫🇷🇩🇪🇮"))
+	assert.Equal(t, []string{"a", "\r\n", "b"}, utils.Graphemes("a\r\nb"))
+	assert.Equal(t, 2, utils.GraphemeCount("日本"))
+}
+
+func TestCountRows(t *testing.T) {
+	assert.Equal(t, 1, utils.CountRows("", 10))
+	assert.Equal(t, 1, utils.CountRows("foo", 10))
+	assert.Equal(t, 3, utils.CountRows("foo\r\n\r\nbar", 10))
+	assert.Equal(t, 1, utils.CountRows("0123456789", 10))
+	assert.Equal(t, 2, utils.CountRows("0123456789a", 10))
+	assert.Equal(t, 4, utils.CountRows("0123456789a\r\n"+picocolors.Cyan("0123456789abcde"), 10))
+	assert.Equal(t, 1, utils.CountRows(picocolors.Cyan("0123456789"), 10))
+	assert.Equal(t, 2, utils.CountRows("0123456789a\nb", 0))
 }
 
 func TestSplitLines(t *testing.T) {
@@ -0,0 +1,176 @@
+package utils
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// wideRanges are the East Asian Wide and Fullwidth ranges of Unicode, along with emojis presented as wide by default.
+var wideRanges = [][2]rune{
+	{0x1100, 0x115f}, {0x231a, 0x231b}, {0x2329, 0x232a}, {0x23e9, 0x23ec}, {0x23f0, 0x23f0},
+	{0x23f3, 0x23f3}, {0x25fd, 0x25fe}, {0x2614, 0x2615}, {0x2648, 0x2653}, {0x267f, 0x267f},
+	{0x2693, 0x2693}, {0x26a1, 0x26a1}, {0x26aa, 0x26ab}, {0x26bd, 0x26be}, {0x26c4, 0x26c5},
+	{0x26ce, 0x26ce}, {0x26d4, 0x26d4}, {0x26ea, 0x26ea}, {0x26f2, 0x26f3}, {0x26f5, 0x26f5},
+	{0x26fa, 0x26fa}, {0x26fd, 0x26fd}, {0x2705, 0x2705}, {0x270a, 0x270b}, {0x2728, 0x2728},
+	{0x274c, 0x274c}, {0x274e, 0x274e}, {0x2753, 0x2755}, {0x2757, 0x2757}, {0x2795, 0x2797},
+	{0x27b0, 0x27b0}, {0x27bf, 0x27bf}, {0x2b1b, 0x2b1c}, {0x2b50, 0x2b50}, {0x2b55, 0x2b55},
+	{0x2e80, 0x303e}, {0x3041, 0x3247}, {0x3250, 0x4dbf}, {0x4e00, 0xa4c6}, {0xa960, 0xa97c},
+	{0xac00, 0xd7a3}, {0xf900, 0xfaff}, {0xfe10, 0xfe19}, {0xfe30, 0xfe6b}, {0xff01, 0xff60},
+	{0xffe0, 0xffe6}, {0x16fe0, 0x16fe4}, {0x16ff0, 0x16ff1}, {0x17000, 0x18cd5}, {0x18d00, 0x18d08},
+	{0x1aff0, 0x1b2fb}, {0x1f004, 0x1f004}, {0x1f0cf, 0x1f0cf}, {0x1f18e, 0x1f18e}, {0x1f191, 0x1f19a},
+	{0x1f200, 0x1f202}, {0x1f210, 0x1f23b}, {0x1f240, 0x1f248}, {0x1f250, 0x1f251}, {0x1f260, 0x1f265},
+	{0x1f300, 0x1f320}, {0x1f32d, 0x1f335}, {0x1f337, 0x1f37c}, {0x1f37e, 0x1f393}, {0x1f3a0, 0x1f3ca},
+	{0x1f3cf, 0x1f3d3}, {0x1f3e0, 0x1f3f0}, {0x1f3f4, 0x1f3f4}, {0x1f3f8, 0x1f43e}, {0x1f440, 0x1f440},
+	{0x1f442, 0x1f4fc}, {0x1f4ff, 0x1f53d}, {0x1f54b, 0x1f54e}, {0x1f550, 0x1f567}, {0x1f57a, 0x1f57a},
+	{0x1f595, 0x1f596}, {0x1f5a4, 0x1f5a4}, {0x1f5fb, 0x1f64f}, {0x1f680, 0x1f6c5}, {0x1f6cc, 0x1f6cc},
+	{0x1f6d0, 0x1f6d2}, {0x1f6d5, 0x1f6d7}, {0x1f6dc, 0x1f6df}, {0x1f6eb, 0x1f6ec}, {0x1f6f4, 0x1f6fc},
+	{0x1f7e0, 0x1f7eb}, {0x1f7f0, 0x1f7f0}, {0x1f90c, 0x1f93a}, {0x1f93c, 0x1f945}, {0x1f947, 0x1f9ff},
+	{0x1fa70, 0x1fa7c}, {0x1fa80, 0x1fa88}, {0x1fa90, 0x1fabd}, {0x1fabf, 0x1fac5}, {0x1face, 0x1fadb},
+	{0x1fae0, 0x1fae8}, {0x1faf0, 0x1faf8}, {0x20000, 0x2fffd}, {0x30000, 0x3fffd},
+}
+
+// isWide reports whether a rune is displayed in two terminal columns.
+func isWide(r rune) bool {
+	i := sort.Search(len(wideRanges), func(i int) bool { return wideRanges[i][1] >= r })
+	return i < len(wideRanges) && wideRanges[i][0] <= r
+}
+
+// isZeroWidth reports whether a rune takes no terminal column, as control characters, combining marks,
+// format characters (e.g. zero-width joiners and spaces) and Hangul medial and final jamos.
+func isZeroWidth(r rune) bool {
+	return isControlCharacter(r) ||
+		unicode.In(r, unicode.Mn, unicode.Me, unicode.Cf) ||
+		(r >= 0x1160 && r <= 0x11ff) ||
+		(r >= 0xd7b0 && r <= 0xd7ff)
+}
+
+// RuneWidth returns the number of terminal columns taken by a rune: 0, 1 or 2.
+func RuneWidth(r rune) int {
+	if isZeroWidth(r) {
+		return 0
+	}
+	if isWide(r) {
+		return 2
+	}
+	return 1
+}
+
+// graphemeWidth returns the number of terminal columns taken by a grapheme cluster.
+// The cluster takes the width of its base character, unless a variation selector changes its presentation:
+// VS16 (U+FE0F) presents narrow symbols as wide emojis, and VS15 (U+FE0E) presents emojis as narrow text.
+// Flags made of two regional indicators are wide.
+func graphemeWidth(cluster string) int {
+	runes := []rune(cluster)
+	base := runes[0]
+	if isRegionalIndicator(base) {
+		if len(runes) > 1 && isRegionalIndicator(runes[1]) {
+			return 2
+		}
+		return 1
+	}
+
+	width := RuneWidth(base)
+	if width == 0 {
+		return 0
+	}
+	if strings.ContainsRune(cluster, 0xfe0e) {
+		return 1
+	}
+	if strings.ContainsRune(cluster, 0xfe0f) {
+		return 2
+	}
+	return width
+}
+
+// escapeLength returns the length of the ANSI escape sequence at the start of a string, or 0 if it does not start with one.
+// It handles CSI sequences (e.g. colors), OSC sequences (e.g. hyperlinks) and two-byte escapes.
+func escapeLength(str string) int {
+	if len(str) == 0 || str[0] != '\x1b' {
+		return 0
+	}
+	if len(str) == 1 {
+		return 1
+	}
+
+	switch str[1] {
+	case '[':
+		for i := 2; i < len(str); i++ {
+			if str[i] >= 0x40 && str[i] <= 0x7e {
+				return i + 1
+			}
+		}
+	case ']':
+		for i := 2; i < len(str); i++ {
+			if str[i] == '\a' {
+				return i + 1
+			}
+			if str[i] == '\x1b' && i+1 < len(str) && str[i+1] == '\\' {
+				return i + 2
+			}
+		}
+	default:
+		return 2
+	}
+	return len(str)
+}
+
+// walkClusters calls fn for each ANSI escape sequence and grapheme cluster of a string, in order, until fn returns false.
+func walkClusters(str string, fn func(segment string, width int) bool) {
+	for len(str) > 0 {
+		if n := escapeLength(str); n > 0 {
+			if !fn(str[:n], 0) {
+				return
+			}
+			str = str[n:]
+			continue
+		}
+
+		end := strings.IndexByte(str, '\x1b')
+		if end == -1 {
+			end = len(str)
+		}
+		for _, cluster := range Graphemes(str[:end]) {
+			if !fn(cluster, graphemeWidth(cluster)) {
+				return
+			}
+		}
+		str = str[end:]
+	}
+}
+
+// StrLength returns the number of terminal columns taken by a string.
+// ANSI escape sequences take no column, East Asian wide characters and emojis take two columns,
+// and combining marks, zero-width joiners and variation selectors are part of the preceding character.
+func StrLength(str string) int {
+	length := 0
+	walkClusters(str, func(segment string, width int) bool {
+		length += width
+		return true
+	})
+	return length
+}
+
+// SplitAtWidth splits a string after the given number of terminal columns.
+// Grapheme clusters and ANSI escape sequences are never split, so a wide character that does not fit starts the tail.
+// The head always holds the first character, even if it does not fit, so that splitting a string repeatedly comes to an end.
+//
+// Parameters:
+//   - str (string): The string to split.
+//   - width (int): The maximum number of columns of the head.
+//
+// Returns:
+//   - head (string): The start of the string, taking at most width columns.
+//   - tail (string): The rest of the string.
+func SplitAtWidth(str string, width int) (head string, tail string) {
+	length, index := 0, 0
+	walkClusters(str, func(segment string, segmentWidth int) bool {
+		if length+segmentWidth > width && length > 0 {
+			return false
+		}
+		length += segmentWidth
+		index += len(segment)
+		return true
+	})
+	return str[:index], str[index:]
+}
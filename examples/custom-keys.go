@@ -7,15 +7,10 @@ import (
 
 func CustomKeys() {
 	core.UpdateSettings(core.SettingsOptions{
-		Aliases: map[core.KeyName]core.Action{
-			"k": core.UpAction,
-			"j": core.DownAction,
-			"h": core.LeftAction,
-			"l": core.RightAction,
-		},
+		Keymap: core.VimKeymap(),
 	})
 
 	prompts.SelectPath(prompts.SelectPathParams{
-		Message: "Try Vim keys to move: (k=up,j=down,h=left,l=right)",
+		Message: "Try Vim keys to move: (k=up,j=down,h=left,l=right,g=first,G=last)",
 	})
 }
@@ -0,0 +1,29 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/orochaa/go-clack/prompts"
+)
+
+func LiveValidation() {
+	taken := map[string]bool{"admin": true, "root": true}
+
+	prompts.Text(prompts.TextParams{
+		Message: "Pick a username.",
+		LiveValidate: func(ctx context.Context, value string) error {
+			// Simulate a slow lookup, which is abandoned as soon as the user keeps typing
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(500 * time.Millisecond):
+			}
+			if taken[value] {
+				return fmt.Errorf("%s is already taken", value)
+			}
+			return nil
+		},
+	})
+}
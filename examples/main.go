@@ -23,6 +23,7 @@ func main() {
 			{Label: "spinner-timer"},
 			{Label: "spinner-ci"},
 			{Label: "async-validation"},
+			{Label: "live-validation"},
 			{Label: "file-selection"},
 			{Label: "race-condition"},
 			{Label: "custom-keys"},
@@ -46,6 +47,8 @@ func main() {
 		SpinnerCIExample()
 	case "async-validation":
 		AsyncValidation()
+	case "live-validation":
+		LiveValidation()
 	case "file-selection":
 		FileSelection()
 	case "race-condition":
@@ -2,24 +2,31 @@ package prompts
 
 import (
 	"context"
-	"os"
+	"io"
 	"strings"
+	"time"
 
 	"github.com/orochaa/go-clack/core"
-	"github.com/orochaa/go-clack/prompts/symbols"
 	"github.com/orochaa/go-clack/prompts/test"
 	"github.com/orochaa/go-clack/prompts/theme"
-	"github.com/orochaa/go-clack/third_party/picocolors"
 )
 
 type ConfirmParams struct {
-	Context      context.Context
-	Input        *os.File
-	Output       *os.File
-	Message      string
-	InitialValue bool
-	Active       string
-	Inactive     string
+	Context       context.Context
+	Input         io.Reader
+	Output        io.Writer
+	Message       string
+	InitialValue  bool
+	Active        string
+	Inactive      string
+	Timeout       time.Duration
+	TimeoutCancel bool
+	Ephemeral     bool
+	LineMode      bool
+	Summary       func(value bool) string
+	Settings      *core.SettingsOptions
+	Theme         theme.Theme
+	FormatValue   func(value bool) string
 }
 
 // Confirm displays a confirmation prompt to the user.
@@ -32,43 +39,61 @@ type ConfirmParams struct {
 //
 // Parameters:
 //   - Context (context.Context): The context for the prompt (default: context.Background).
-//   - Input (*os.File): The input stream for the prompt (default: OSFileSystem).
-//   - Output (*os.File): The output stream for the prompt (default: OSFileSystem).
+//   - Input (io.Reader): The input stream for the prompt (default: os.Stdin).
+//   - Output (io.Writer): The output stream for the prompt (default: os.Stdout).
 //   - Message (string): The message to display to the user (default: "").
 //   - InitialValue (bool): The initial value of the prompt (default: false).
 //   - Active (string): The active option to display (default: "yes").
 //   - Inactive (string): The inactive option to display (default: "no").
+//   - Timeout (time.Duration): The time after which the current value is submitted, unless the user presses a key before (default: 0, no timeout).
+//   - TimeoutCancel (bool): Whether to cancel the prompt with core.ErrTimeout instead of submitting the current value on timeout (default: false).
+//   - Ephemeral (bool): Whether to erase the prompt once submitted, or replace it by its Summary (default: false).
+//   - LineMode (bool): Whether to read whole lines as answers, without raw mode or cursor movements, as done when the input is not a terminal (default: false).
+//   - Summary (func(value bool) string): The one-line summary of the submitted value of an ephemeral prompt (default: nil, the prompt is erased).
+//   - Settings (*core.SettingsOptions): The key bindings and options of the prompt (default: core.Settings).
+//   - Theme (theme.Theme): The look of the prompt (default: theme.Current()).
+//   - FormatValue (func(value bool) string): Formats the value displayed once the prompt is submitted or cancelled (default: nil).
 //
 // Returns:
 //   - bool: The selected value if the user confirms their choice.
 //   - error: An error if the user cancels the prompt or if an error occurs.
 func Confirm(params ConfirmParams) (bool, error) {
 	p := core.NewConfirmPrompt(core.ConfirmPromptParams{
-		Context:      params.Context,
-		Input:        params.Input,
-		Output:       params.Output,
-		InitialValue: params.InitialValue,
-		Active:       params.Active,
-		Inactive:     params.Inactive,
+		Context:       params.Context,
+		Input:         params.Input,
+		Output:        params.Output,
+		InitialValue:  params.InitialValue,
+		Active:        params.Active,
+		Inactive:      params.Inactive,
+		Timeout:       params.Timeout,
+		TimeoutCancel: params.TimeoutCancel,
+		Ephemeral:     params.Ephemeral,
+		LineMode:      params.LineMode,
+		Summary:       params.Summary,
+		Settings:      params.Settings,
 		Render: func(p *core.ConfirmPrompt) string {
-			activeRadio := picocolors.Green(symbols.RADIO_ACTIVE)
-			inactiveRadio := picocolors.Dim(symbols.RADIO_INACTIVE)
-			slash := picocolors.Dim("/")
+			t := theme.Resolve(params.Theme)
+			slash := t.Option("/", false)
 
-			var value, valueWithCursor string
+			var value string
 			if p.Value {
 				value = p.Active
-				valueWithCursor = strings.Join([]string{activeRadio, p.Active, slash, inactiveRadio, picocolors.Dim(p.Inactive)}, " ")
 			} else {
 				value = p.Inactive
-				valueWithCursor = strings.Join([]string{inactiveRadio, picocolors.Dim(p.Active), slash, activeRadio, p.Inactive}, " ")
 			}
+			valueWithCursor := strings.Join([]string{
+				t.Radio(p.Value), t.Option(p.Active, p.Value),
+				slash,
+				t.Radio(!p.Value), t.Option(p.Inactive, !p.Value),
+			}, " ")
 
 			return theme.ApplyTheme(theme.ThemeParams[bool]{
 				Context:         p.Prompt,
+				Theme:           t,
 				Message:         params.Message,
-				Value:           value,
+				Value:           formatValue(p.State, p.Value, params.FormatValue, value),
 				ValueWithCursor: valueWithCursor,
+				Placeholder:     linePlaceholder(p.LineMode, "y/n"),
 			})
 		},
 	})
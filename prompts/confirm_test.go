@@ -13,7 +13,7 @@ import (
 )
 
 func TestConfirmInitialState(t *testing.T) {
-	go prompts.Confirm(prompts.ConfirmParams{Message: message})
+	go prompts.Confirm(prompts.ConfirmParams{Input: MockInput{}, Message: message})
 	time.Sleep(time.Millisecond)
 
 	p := test.ConfirmTestingPrompt
@@ -25,7 +25,7 @@ func TestConfirmInitialState(t *testing.T) {
 }
 
 func TestConfirmInitialStateWithInitialValue(t *testing.T) {
-	go prompts.Confirm(prompts.ConfirmParams{Message: message, InitialValue: true})
+	go prompts.Confirm(prompts.ConfirmParams{Input: MockInput{}, Message: message, InitialValue: true})
 	time.Sleep(time.Millisecond)
 
 	p := test.ConfirmTestingPrompt
@@ -37,7 +37,7 @@ func TestConfirmInitialStateWithInitialValue(t *testing.T) {
 }
 
 func TestConfirmCancelState(t *testing.T) {
-	go prompts.Confirm(prompts.ConfirmParams{Message: message})
+	go prompts.Confirm(prompts.ConfirmParams{Input: MockInput{}, Message: message})
 	time.Sleep(time.Millisecond)
 
 	p := test.ConfirmTestingPrompt
@@ -51,7 +51,7 @@ func TestConfirmCancelState(t *testing.T) {
 }
 
 func TestConfirmCancelStateWithValue(t *testing.T) {
-	go prompts.Confirm(prompts.ConfirmParams{Message: message, InitialValue: true})
+	go prompts.Confirm(prompts.ConfirmParams{Input: MockInput{}, Message: message, InitialValue: true})
 	time.Sleep(time.Millisecond)
 
 	p := test.ConfirmTestingPrompt
@@ -65,7 +65,7 @@ func TestConfirmCancelStateWithValue(t *testing.T) {
 }
 
 func TestConfirmSubmitState(t *testing.T) {
-	go prompts.Confirm(prompts.ConfirmParams{Message: message})
+	go prompts.Confirm(prompts.ConfirmParams{Input: MockInput{}, Message: message})
 	time.Sleep(time.Millisecond)
 
 	p := test.ConfirmTestingPrompt
@@ -77,3 +77,13 @@ func TestConfirmSubmitState(t *testing.T) {
 	assert.Equal(t, core.SubmitState, p.State)
 	assert.Equal(t, expected, p.Frame)
 }
+
+func TestConfirmLineMode(t *testing.T) {
+	go prompts.Confirm(prompts.ConfirmParams{Input: MockInput{}, Message: message})
+	time.Sleep(time.Millisecond)
+
+	p := test.ConfirmTestingPrompt
+	p.LineMode = true
+
+	assert.Equal(t, message+" (y/n) ", p.Render(&p.Prompt))
+}
@@ -2,20 +2,19 @@ package prompts
 
 import (
 	"context"
-	"os"
+	"io"
+	"time"
 
 	"github.com/orochaa/go-clack/core"
 	"github.com/orochaa/go-clack/core/validator"
-	"github.com/orochaa/go-clack/prompts/symbols"
 	"github.com/orochaa/go-clack/prompts/test"
 	"github.com/orochaa/go-clack/prompts/theme"
-	"github.com/orochaa/go-clack/third_party/picocolors"
 )
 
 type GroupMultiSelectParams[TValue comparable] struct {
 	Context        context.Context
-	Input          *os.File
-	Output         *os.File
+	Input          io.Reader
+	Output         io.Writer
 	Message        string
 	Options        map[string][]MultiSelectOption[TValue]
 	InitialValue   []TValue
@@ -23,6 +22,15 @@ type GroupMultiSelectParams[TValue comparable] struct {
 	SpacedGroups   bool
 	Required       bool
 	Validate       func(value []TValue) error
+	Mouse          bool
+	Timeout        time.Duration
+	TimeoutCancel  bool
+	Ephemeral      bool
+	LineMode       bool
+	Summary        func(value []TValue) string
+	Settings       *core.SettingsOptions
+	Theme          theme.Theme
+	FormatValue    func(value []TValue) string
 }
 
 // GroupMultiSelect displays a grouped multi select prompt to the user.
@@ -36,8 +44,8 @@ type GroupMultiSelectParams[TValue comparable] struct {
 //
 // Parameters:
 //   - Context (context.Context): The context for the prompt (default: context.Background).
-//   - Input (*os.File): The input stream for the prompt (default: OSFileSystem).
-//   - Output (*os.File): The output stream for the prompt (default: OSFileSystem).
+//   - Input (io.Reader): The input stream for the prompt (default: os.Stdin).
+//   - Output (io.Writer): The output stream for the prompt (default: os.Stdout).
 //   - Message (string): The message to display to the user (default: "").
 //   - Options (map[string][]MultiSelectOption[TValue]):
 //     A map of group names to a slice of MultiSelectOption[TValue] values.
@@ -47,6 +55,15 @@ type GroupMultiSelectParams[TValue comparable] struct {
 //   - SpacedGroups (bool): Whether the groups are spaced out (default: false).
 //   - Required (bool): Whether the prompt is required (default: false).
 //   - Validate (func(value []TValue) error): Custom validation function for the prompt (default: nil).
+//   - Mouse (bool): Whether to select options by clicking and scroll them with the wheel (default: core.Settings.Mouse).
+//   - Timeout (time.Duration): The time after which the current value is submitted, unless the user presses a key before (default: 0, no timeout).
+//   - TimeoutCancel (bool): Whether to cancel the prompt with core.ErrTimeout instead of submitting the current value on timeout (default: false).
+//   - Ephemeral (bool): Whether to erase the prompt once submitted, or replace it by its Summary (default: false).
+//   - LineMode (bool): Whether to read whole lines as answers, without raw mode or cursor movements, as done when the input is not a terminal (default: false).
+//   - Summary (func(value []TValue) string): The one-line summary of the submitted value of an ephemeral prompt (default: nil, the prompt is erased).
+//   - Settings (*core.SettingsOptions): The key bindings and options of the prompt (default: core.Settings).
+//   - Theme (theme.Theme): The look of the prompt (default: theme.Current()).
+//   - FormatValue (func(value []TValue) string): Formats the value displayed once the prompt is submitted or cancelled (default: nil).
 //
 // Returns:
 //   - []TValue: The values of the selected options.
@@ -77,7 +94,15 @@ func GroupMultiSelect[TValue comparable](params GroupMultiSelectParams[TValue])
 		DisabledGroups: params.DisabledGroups,
 		Required:       params.Required,
 		Validate:       params.Validate,
+		Mouse:          params.Mouse,
+		Timeout:        params.Timeout,
+		TimeoutCancel:  params.TimeoutCancel,
+		Ephemeral:      params.Ephemeral,
+		LineMode:       params.LineMode,
+		Summary:        params.Summary,
+		Settings:       params.Settings,
 		Render: func(p *core.GroupMultiSelectPrompt[TValue]) string {
+			t := theme.Resolve(params.Theme)
 			var value string
 
 			switch p.State {
@@ -96,22 +121,23 @@ func GroupMultiSelect[TValue comparable](params GroupMultiSelectParams[TValue])
 				radioOptions := make([]string, len(p.Options))
 				for i, option := range p.Options {
 					if option.IsGroup {
-						radioOptions[i] = groupOption(option, p.IsGroupSelected(option), i == p.CursorIndex, p.DisabledGroups)
+						radioOptions[i] = groupOption(t, option, p.IsGroupSelected(option), i == p.CursorIndex, p.DisabledGroups)
 						if params.SpacedGroups && i > 0 {
 							radioOptions[i] = "\r\n" + radioOptions[i]
 						}
 						continue
 					}
 
-					radioOptions[i] = " " + groupOption(option, option.IsSelected, i == p.CursorIndex, false)
+					radioOptions[i] = " " + groupOption(t, option, option.IsSelected, i == p.CursorIndex, false)
 				}
 				value = p.LimitLines(radioOptions, 3)
 			}
 
 			return theme.ApplyTheme(theme.ThemeParams[[]TValue]{
 				Context:         p.Prompt,
+				Theme:           t,
 				Message:         params.Message,
-				Value:           value,
+				Value:           formatValue(p.State, p.Value, params.FormatValue, value),
 				ValueWithCursor: value,
 			})
 		},
@@ -120,30 +146,12 @@ func GroupMultiSelect[TValue comparable](params GroupMultiSelectParams[TValue])
 	return p.Run()
 }
 
-func groupOption[TValue comparable](option *core.GroupMultiSelectOption[TValue], isSelected, isActive, isDisabled bool) string {
-	var radio, label, hint string
-
-	if isSelected && isActive {
-		radio = picocolors.Green(symbols.CHECKBOX_SELECTED)
-		label = option.Label
-		if option.Hint != "" {
-			hint = picocolors.Dim("(" + option.Hint + ")")
-		}
-	} else if isActive {
-		radio = picocolors.Green(symbols.CHECKBOX_ACTIVE)
-		label = option.Label
-		if option.Hint != "" {
-			hint = picocolors.Dim("(" + option.Hint + ")")
-		}
-	} else if isSelected {
-		radio = picocolors.Green(symbols.CHECKBOX_SELECTED)
-		label = picocolors.Dim(option.Label)
-		if option.Hint != "" {
-			hint = picocolors.Dim("(" + option.Hint + ")")
-		}
-	} else {
-		radio = picocolors.Dim(symbols.CHECKBOX_INACTIVE)
-		label = picocolors.Dim(option.Label)
+func groupOption[TValue comparable](t theme.Theme, option *core.GroupMultiSelectOption[TValue], isSelected, isActive, isDisabled bool) string {
+	radio := t.Checkbox(isActive, isSelected)
+	label := t.Option(option.Label, isActive)
+	var hint string
+	if isActive || isSelected {
+		hint = t.Hint(option.Hint)
 	}
 
 	if isDisabled {
@@ -13,6 +13,7 @@ import (
 
 func runGroupMultiSelect() {
 	prompts.GroupMultiSelect(prompts.GroupMultiSelectParams[string]{
+		Input:   MockInput{},
 		Message: message,
 		Options: map[string][]prompts.MultiSelectOption[string]{
 			"1": {
@@ -64,6 +65,7 @@ func TestGroupMultiSelectSubmitState(t *testing.T) {
 
 func TestGroupMultiSelectWithLongList(t *testing.T) {
 	go prompts.GroupMultiSelect(prompts.GroupMultiSelectParams[string]{
+		Input:   MockInput{},
 		Message: message,
 		Options: map[string][]prompts.MultiSelectOption[string]{
 			"1": {
@@ -108,6 +110,7 @@ func TestGroupMultiSelectMultiValue(t *testing.T) {
 
 func TestGroupMultiSelectDisabledGroups(t *testing.T) {
 	go prompts.GroupMultiSelect(prompts.GroupMultiSelectParams[string]{
+		Input:          MockInput{},
 		DisabledGroups: true,
 		Message:        message,
 		Options: map[string][]prompts.MultiSelectOption[string]{
@@ -130,6 +133,7 @@ func TestGroupMultiSelectDisabledGroups(t *testing.T) {
 
 func TestGroupMultiSelectSpacedGroups(t *testing.T) {
 	go prompts.GroupMultiSelect(prompts.GroupMultiSelectParams[string]{
+		Input:        MockInput{},
 		SpacedGroups: true,
 		Message:      message,
 		Options: map[string][]prompts.MultiSelectOption[string]{
@@ -152,6 +156,7 @@ func TestGroupMultiSelectSpacedGroups(t *testing.T) {
 
 func TestGroupMultiSelectDisabledAndSpacedGroups(t *testing.T) {
 	go prompts.GroupMultiSelect(prompts.GroupMultiSelectParams[string]{
+		Input:          MockInput{},
 		DisabledGroups: true,
 		SpacedGroups:   true,
 		Message:        message,
@@ -8,6 +8,7 @@ import (
 	"github.com/orochaa/go-clack/core"
 	"github.com/orochaa/go-clack/core/utils"
 	"github.com/orochaa/go-clack/prompts/symbols"
+	"github.com/orochaa/go-clack/prompts/theme"
 	"github.com/orochaa/go-clack/third_party/picocolors"
 )
 
@@ -17,7 +18,18 @@ type MessageOptions = core.FormatLinesOptions
 func Message(msg string, options MessageOptions) {
 	p := &core.Prompt[string]{}
 	formattedMsg := p.FormatLines(utils.SplitLines(msg), options)
-	os.Stdout.WriteString(fmt.Sprintf("%s\r\n%s\r\n", picocolors.Gray(symbols.BAR), formattedMsg))
+	if bar := grayBar(theme.Current().Bar()); bar != "" {
+		formattedMsg = bar + "\r\n" + formattedMsg
+	}
+	os.Stdout.WriteString(formattedMsg + "\r\n")
+}
+
+// grayBar styles a bar glyph of the current theme, or returns an empty string if the theme has no bars.
+func grayBar(glyph string) string {
+	if glyph == "" {
+		return ""
+	}
+	return picocolors.Gray(glyph)
 }
 
 func styleMsg(msg string, style func(msg string) string) string {
@@ -34,35 +46,41 @@ func Intro(msg string) {
 	p := &core.Prompt[string]{}
 	formattedMsg := p.FormatLines(utils.SplitLines(msg), MessageOptions{
 		FirstLine: MessageLineOptions{
-			Start: picocolors.Gray(symbols.BAR_START),
+			Start: grayBar(theme.Current().BarStart()),
 		},
 		NewLine: MessageLineOptions{
-			Start: picocolors.Gray(symbols.BAR),
+			Start: grayBar(theme.Current().Bar()),
 		},
 	})
-	os.Stdout.WriteString(fmt.Sprintf("\r\n%s\r\n%s\r\n", formattedMsg, picocolors.Gray(symbols.BAR)))
+	if bar := grayBar(theme.Current().Bar()); bar != "" {
+		formattedMsg += "\r\n" + bar
+	}
+	os.Stdout.WriteString(fmt.Sprintf("\r\n%s\r\n", formattedMsg))
 }
 
 // Cancel displays a cancellation message styled in red.
 func Cancel(msg string) {
 	Message(styleMsg(msg, picocolors.Red), MessageOptions{
 		Default: MessageLineOptions{
-			Start: picocolors.Gray(symbols.BAR),
+			Start: grayBar(theme.Current().Bar()),
 		},
 		LastLine: MessageLineOptions{
-			Start: picocolors.Gray(symbols.BAR_END),
+			Start: grayBar(theme.Current().BarEnd()),
 		},
 	})
 }
 
-// Outro displays a closing message.
+// Outro displays a closing message, below a blank bar line if the current theme has bars.
 func Outro(msg string) {
-	Message("\r\n"+msg, MessageOptions{
+	if theme.Current().Bar() != "" {
+		msg = "\r\n" + msg
+	}
+	Message(msg, MessageOptions{
 		Default: MessageLineOptions{
-			Start: picocolors.Gray(symbols.BAR),
+			Start: grayBar(theme.Current().Bar()),
 		},
 		LastLine: MessageLineOptions{
-			Start: picocolors.Gray(symbols.BAR_END),
+			Start: grayBar(theme.Current().BarEnd()),
 		},
 	})
 }
@@ -74,7 +92,7 @@ func Info(msg string) {
 			Start: picocolors.Blue(symbols.INFO),
 		},
 		NewLine: MessageLineOptions{
-			Start: picocolors.Gray(symbols.BAR),
+			Start: grayBar(theme.Current().Bar()),
 		},
 	})
 }
@@ -86,19 +104,20 @@ func Success(msg string) {
 			Start: picocolors.Green(symbols.SUCCESS),
 		},
 		NewLine: MessageLineOptions{
-			Start: picocolors.Gray(symbols.BAR),
+			Start: grayBar(theme.Current().Bar()),
 		},
 	})
 }
 
-// Step displays a step message with a green step symbol.
+// Step displays a step message with the submit symbol of the current theme.
 func Step(msg string) {
+	t := theme.Current()
 	Message(msg, MessageOptions{
 		FirstLine: MessageLineOptions{
-			Start: picocolors.Green(symbols.STEP_SUBMIT),
+			Start: t.SymbolColor(core.SubmitState)(t.Symbol(core.SubmitState)),
 		},
 		NewLine: MessageLineOptions{
-			Start: picocolors.Gray(symbols.BAR),
+			Start: grayBar(theme.Current().Bar()),
 		},
 	})
 }
@@ -110,7 +129,7 @@ func Warn(msg string) {
 			Start: picocolors.Yellow(symbols.WARN),
 		},
 		NewLine: MessageLineOptions{
-			Start: picocolors.Gray(symbols.BAR),
+			Start: grayBar(theme.Current().Bar()),
 		},
 	})
 }
@@ -122,7 +141,7 @@ func Error(msg string) {
 			Start: picocolors.Red(symbols.ERROR),
 		},
 		NewLine: MessageLineOptions{
-			Start: picocolors.Gray(symbols.BAR),
+			Start: grayBar(theme.Current().Bar()),
 		},
 	})
 }
@@ -1,6 +1,7 @@
 package prompts_test
 
 import (
+	"io"
 	"os"
 	"sync"
 	"time"
@@ -47,6 +48,13 @@ func (fs MockFileSystem) UserHomeDir() (string, error) {
 	return "/home/clack", nil
 }
 
+// MockInput is the input of the prompts under test, which are driven by pressing their keys instead.
+type MockInput struct{}
+
+func (i MockInput) Read(p []byte) (int, error) {
+	return 0, io.EOF
+}
+
 type MockTimer struct {
 	mu          sync.Mutex
 	waiters     []chan struct{}
@@ -3,28 +3,36 @@ package prompts
 import (
 	"context"
 	"fmt"
-	"os"
+	"io"
 	"strings"
+	"time"
 
 	"github.com/orochaa/go-clack/core"
-	"github.com/orochaa/go-clack/prompts/symbols"
 	"github.com/orochaa/go-clack/prompts/test"
 	"github.com/orochaa/go-clack/prompts/theme"
-	"github.com/orochaa/go-clack/third_party/picocolors"
 )
 
 type MultiSelectPathParams struct {
-	Context      context.Context
-	Input        *os.File
-	Output       *os.File
-	Message      string
-	InitialValue []string
-	InitialPath  string
-	Required     bool
-	OnlyShowDir  bool
-	Filter       bool
-	FileSystem   FileSystem
-	Validate     func(value []string) error
+	Context       context.Context
+	Input         io.Reader
+	Output        io.Writer
+	Message       string
+	InitialValue  []string
+	InitialPath   string
+	Required      bool
+	OnlyShowDir   bool
+	Filter        bool
+	FileSystem    FileSystem
+	Validate      func(value []string) error
+	Mouse         bool
+	Timeout       time.Duration
+	TimeoutCancel bool
+	Ephemeral     bool
+	LineMode      bool
+	Summary       func(value []string) string
+	Settings      *core.SettingsOptions
+	Theme         theme.Theme
+	FormatValue   func(value []string) string
 }
 
 // MultiSelectPath displays a multi-select prompt to the user.
@@ -38,8 +46,8 @@ type MultiSelectPathParams struct {
 //
 // Parameters:
 //   - Context (context.Context): The context for the prompt (default: context.Background).
-//   - Input (*os.File): The input stream for the prompt (default: OSFileSystem).
-//   - Output (*os.File): The output stream for the prompt (default: OSFileSystem).
+//   - Input (io.Reader): The input stream for the prompt (default: os.Stdin).
+//   - Output (io.Writer): The output stream for the prompt (default: os.Stdout).
 //   - Message (string): The message to display to the user (default: "").
 //   - InitialValue ([]string): Initial selected paths (default: nil).
 //   - InitialPath (string): The initial directory path to start from (default: current working directory).
@@ -48,23 +56,40 @@ type MultiSelectPathParams struct {
 //   - Filter (bool): Whether to enable filtering of options (default: false).
 //   - FileSystem (FileSystem): The file system implementation to use (default: OSFileSystem).
 //   - Validate (func(value []TValue) error): Custom validation function for the prompt (default: nil).
+//   - Mouse (bool): Whether to select options by clicking and scroll them with the wheel (default: core.Settings.Mouse).
+//   - Timeout (time.Duration): The time after which the current value is submitted, unless the user presses a key before (default: 0, no timeout).
+//   - TimeoutCancel (bool): Whether to cancel the prompt with core.ErrTimeout instead of submitting the current value on timeout (default: false).
+//   - Ephemeral (bool): Whether to erase the prompt once submitted, or replace it by its Summary (default: false).
+//   - LineMode (bool): Whether to read whole lines as answers, without raw mode or cursor movements, as done when the input is not a terminal (default: false).
+//   - Summary (func(value []string) string): The one-line summary of the submitted value of an ephemeral prompt (default: nil, the prompt is erased).
+//   - Settings (*core.SettingsOptions): The key bindings and options of the prompt (default: core.Settings).
+//   - Theme (theme.Theme): The look of the prompt (default: theme.Current()).
+//   - FormatValue (func(value []string) string): Formats the value displayed once the prompt is submitted or cancelled (default: nil).
 //
 // Returns:
 //   - []string: A slice of paths of the selected options.
 //   - error: An error if the user cancels the prompt or if an error occurs.
 func MultiSelectPath(params MultiSelectPathParams) ([]string, error) {
 	p := core.NewMultiSelectPathPrompt(core.MultiSelectPathPromptParams{
-		Context:      params.Context,
-		Input:        params.Input,
-		Output:       params.Output,
-		InitialValue: params.InitialValue,
-		InitialPath:  params.InitialPath,
-		OnlyShowDir:  params.OnlyShowDir,
-		FileSystem:   params.FileSystem,
-		Required:     params.Required,
-		Filter:       params.Filter,
-		Validate:     params.Validate,
+		Context:       params.Context,
+		Input:         params.Input,
+		Output:        params.Output,
+		InitialValue:  params.InitialValue,
+		InitialPath:   params.InitialPath,
+		OnlyShowDir:   params.OnlyShowDir,
+		FileSystem:    params.FileSystem,
+		Required:      params.Required,
+		Filter:        params.Filter,
+		Validate:      params.Validate,
+		Mouse:         params.Mouse,
+		Timeout:       params.Timeout,
+		TimeoutCancel: params.TimeoutCancel,
+		Ephemeral:     params.Ephemeral,
+		LineMode:      params.LineMode,
+		Summary:       params.Summary,
+		Settings:      params.Settings,
 		Render: func(p *core.MultiSelectPathPrompt) string {
+			t := theme.Resolve(params.Theme)
 			message := params.Message
 			var value string
 
@@ -80,28 +105,19 @@ func MultiSelectPath(params MultiSelectPathParams) ([]string, error) {
 					} else if option.IsDir {
 						dir = ">"
 					}
-					if option.IsSelected && option.IsEqual(p.CurrentOption) {
-						radio = picocolors.Green(symbols.CHECKBOX_SELECTED)
-						label = option.Name
-					} else if option.IsSelected {
-						radio = picocolors.Green(symbols.CHECKBOX_SELECTED)
-						label = picocolors.Dim(option.Name)
-						dir = picocolors.Dim(dir)
-					} else if option.IsEqual(p.CurrentOption) {
-						radio = picocolors.Green(symbols.CHECKBOX_ACTIVE)
-						label = option.Name
-					} else {
-						radio = picocolors.Dim(symbols.CHECKBOX_INACTIVE)
-						label = picocolors.Dim(option.Name)
-						dir = picocolors.Dim(dir)
+					isActive := option.IsEqual(p.CurrentOption)
+					radio = t.Checkbox(isActive, option.IsSelected)
+					label = t.Option(option.Name, isActive)
+					if !isActive {
+						dir = t.Option(dir, false)
 					}
 					depth := strings.Repeat(" ", option.Depth)
 					radioOptions[i] = fmt.Sprintf("%s%s %s %s", depth, radio, label, dir)
 				}
 
-				if p.Filter {
+				if p.Filter && !p.LineMode {
 					if p.Search == "" {
-						message = fmt.Sprintf("%s\n> %s", message, picocolors.Inverse("T")+picocolors.Dim("ype to filter..."))
+						message = fmt.Sprintf("%s\n> %s", message, t.Placeholder("Type to filter...", true))
 					} else {
 						message = fmt.Sprintf("%s\n> %s", message, p.Search+"█")
 					}
@@ -115,8 +131,9 @@ func MultiSelectPath(params MultiSelectPathParams) ([]string, error) {
 
 			return theme.ApplyTheme(theme.ThemeParams[[]string]{
 				Context:         p.Prompt,
+				Theme:           t,
 				Message:         message,
-				Value:           strings.Join(p.Value, "\r\n"),
+				Value:           formatValue(p.State, p.Value, params.FormatValue, strings.Join(p.Value, "\r\n")),
 				ValueWithCursor: value,
 			})
 		},
@@ -13,6 +13,7 @@ import (
 
 func runMultiSelectPath() {
 	prompts.MultiSelectPath(prompts.MultiSelectPathParams{
+		Input:      MockInput{},
 		Message:    message,
 		FileSystem: (prompts.FileSystem)(MockFileSystem{}),
 	})
@@ -81,6 +82,7 @@ func TestMultiSelectPathSubmitState(t *testing.T) {
 
 func TestMultiSelectPathEmptyFilter(t *testing.T) {
 	go prompts.MultiSelectPath(prompts.MultiSelectPathParams{
+		Input:      MockInput{},
 		Message:    message,
 		FileSystem: (prompts.FileSystem)(MockFileSystem{}),
 		Filter:     true,
@@ -95,6 +97,7 @@ func TestMultiSelectPathEmptyFilter(t *testing.T) {
 
 func TestMultiSelectPathFilledFilter(t *testing.T) {
 	go prompts.MultiSelectPath(prompts.MultiSelectPathParams{
+		Input:      MockInput{},
 		Message:    message,
 		FileSystem: (prompts.FileSystem)(MockFileSystem{}),
 		Filter:     true,
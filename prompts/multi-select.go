@@ -3,14 +3,13 @@ package prompts
 import (
 	"context"
 	"fmt"
-	"os"
+	"io"
+	"time"
 
 	"github.com/orochaa/go-clack/core"
 	"github.com/orochaa/go-clack/core/validator"
-	"github.com/orochaa/go-clack/prompts/symbols"
 	"github.com/orochaa/go-clack/prompts/test"
 	"github.com/orochaa/go-clack/prompts/theme"
-	"github.com/orochaa/go-clack/third_party/picocolors"
 )
 
 type MultiSelectOption[TValue comparable] struct {
@@ -21,15 +20,24 @@ type MultiSelectOption[TValue comparable] struct {
 }
 
 type MultiSelectParams[TValue comparable] struct {
-	Context      context.Context
-	Input        *os.File
-	Output       *os.File
-	Message      string
-	Options      []*MultiSelectOption[TValue]
-	InitialValue []TValue
-	Filter       bool
-	Required     bool
-	Validate     func(value []TValue) error
+	Context       context.Context
+	Input         io.Reader
+	Output        io.Writer
+	Message       string
+	Options       []*MultiSelectOption[TValue]
+	InitialValue  []TValue
+	Filter        bool
+	Required      bool
+	Validate      func(value []TValue) error
+	Mouse         bool
+	Timeout       time.Duration
+	TimeoutCancel bool
+	Ephemeral     bool
+	LineMode      bool
+	Summary       func(value []TValue) string
+	Settings      *core.SettingsOptions
+	Theme         theme.Theme
+	FormatValue   func(value []TValue) string
 }
 
 // MultiSelect displays a multi-select prompt to the user.
@@ -43,14 +51,23 @@ type MultiSelectParams[TValue comparable] struct {
 //
 // Parameters:
 //   - Context (context.Context): The context for the prompt (default: context.Background).
-//   - Input (*os.File): The input stream for the prompt (default: OSFileSystem).
-//   - Output (*os.File): The output stream for the prompt (default: OSFileSystem).
+//   - Input (io.Reader): The input stream for the prompt (default: os.Stdin).
+//   - Output (io.Writer): The output stream for the prompt (default: os.Stdout).
 //   - Message (string): The message to display to the user (default: "").
 //   - Options ([]*MultiSelectOption[TValue]): A list of options for the prompt (default: nil.
 //   - InitialValue ([]TValue): The initial selected values (default: nil.
 //   - Filter (bool): Whether to enable filtering of options (default: false).
 //   - Required (bool): Whether the prompt requires at least one selection (default: false).
 //   - Validate (func(value []TValue) error): Custom validation function for the prompt (default: nil).
+//   - Mouse (bool): Whether to select options by clicking and scroll them with the wheel (default: core.Settings.Mouse).
+//   - Timeout (time.Duration): The time after which the current value is submitted, unless the user presses a key before (default: 0, no timeout).
+//   - TimeoutCancel (bool): Whether to cancel the prompt with core.ErrTimeout instead of submitting the current value on timeout (default: false).
+//   - Ephemeral (bool): Whether to erase the prompt once submitted, or replace it by its Summary (default: false).
+//   - LineMode (bool): Whether to read whole lines as answers, without raw mode or cursor movements, as done when the input is not a terminal (default: false).
+//   - Summary (func(value []TValue) string): The one-line summary of the submitted value of an ephemeral prompt (default: nil, the prompt is erased).
+//   - Settings (*core.SettingsOptions): The key bindings and options of the prompt (default: core.Settings).
+//   - Theme (theme.Theme): The look of the prompt (default: theme.Current()).
+//   - FormatValue (func(value []TValue) string): Formats the value displayed once the prompt is submitted or cancelled (default: nil).
 //
 // Returns:
 //   - []TValue: A slice of values of the selected options.
@@ -70,15 +87,23 @@ func MultiSelect[TValue comparable](params MultiSelectParams[TValue]) ([]TValue,
 	}
 
 	p := core.NewMultiSelectPrompt(core.MultiSelectPromptParams[TValue]{
-		Context:      params.Context,
-		Input:        params.Input,
-		Output:       params.Output,
-		InitialValue: params.InitialValue,
-		Options:      options,
-		Filter:       params.Filter,
-		Required:     params.Required,
-		Validate:     params.Validate,
+		Context:       params.Context,
+		Input:         params.Input,
+		Output:        params.Output,
+		InitialValue:  params.InitialValue,
+		Options:       options,
+		Filter:        params.Filter,
+		Required:      params.Required,
+		Validate:      params.Validate,
+		Mouse:         params.Mouse,
+		Timeout:       params.Timeout,
+		TimeoutCancel: params.TimeoutCancel,
+		Ephemeral:     params.Ephemeral,
+		LineMode:      params.LineMode,
+		Summary:       params.Summary,
+		Settings:      params.Settings,
 		Render: func(p *core.MultiSelectPrompt[TValue]) string {
+			t := theme.Resolve(params.Theme)
 			message := params.Message
 			var value string
 
@@ -97,35 +122,19 @@ func MultiSelect[TValue comparable](params MultiSelectParams[TValue]) ([]TValue,
 			default:
 				radioOptions := make([]string, len(p.Options))
 				for i, option := range p.Options {
-					var radio, label, hint string
-					if option.IsSelected && i == p.CursorIndex {
-						radio = picocolors.Green(symbols.CHECKBOX_SELECTED)
-						label = option.Label
-						if option.Hint != "" {
-							hint = picocolors.Dim("(" + option.Hint + ")")
-						}
-					} else if i == p.CursorIndex {
-						radio = picocolors.Green(symbols.CHECKBOX_ACTIVE)
-						label = option.Label
-						if option.Hint != "" {
-							hint = picocolors.Dim("(" + option.Hint + ")")
-						}
-					} else if option.IsSelected {
-						radio = picocolors.Green(symbols.CHECKBOX_SELECTED)
-						label = picocolors.Dim(option.Label)
-						if option.Hint != "" {
-							hint = picocolors.Dim("(" + option.Hint + ")")
-						}
-					} else {
-						radio = picocolors.Dim(symbols.CHECKBOX_INACTIVE)
-						label = picocolors.Dim(option.Label)
+					isActive := i == p.CursorIndex
+					radio := t.Checkbox(isActive, option.IsSelected)
+					label := t.Option(option.Label, isActive)
+					var hint string
+					if isActive || option.IsSelected {
+						hint = t.Hint(option.Hint)
 					}
 					radioOptions[i] = radio + " " + label + " " + hint
 				}
 
-				if p.Filter {
+				if p.Filter && !p.LineMode {
 					if p.Search == "" {
-						message = fmt.Sprintf("%s\n> %s", message, picocolors.Inverse("T")+picocolors.Dim("ype to filter..."))
+						message = fmt.Sprintf("%s\n> %s", message, t.Placeholder("Type to filter...", true))
 					} else {
 						message = fmt.Sprintf("%s\n> %s", message, p.Search+"█")
 					}
@@ -139,9 +148,14 @@ func MultiSelect[TValue comparable](params MultiSelectParams[TValue]) ([]TValue,
 
 			return theme.ApplyTheme(theme.ThemeParams[[]TValue]{
 				Context:         p.Prompt,
+				Theme:           t,
 				Message:         message,
-				Value:           value,
+				Value:           formatValue(p.State, p.Value, params.FormatValue, value),
 				ValueWithCursor: value,
+				Placeholder:     linePlaceholder(p.LineMode, "comma-separated"),
+				Options: lineOptions(p.LineMode, p.Options, func(option *core.MultiSelectOption[TValue]) string {
+					return option.Label
+				}),
 			})
 		},
 	})
@@ -1,6 +1,7 @@
 package prompts_test
 
 import (
+	"fmt"
 	"testing"
 	"time"
 
@@ -13,6 +14,7 @@ import (
 
 func runMultiSelect() {
 	prompts.MultiSelect(prompts.MultiSelectParams[string]{
+		Input:   MockInput{},
 		Message: message,
 		Options: []*prompts.MultiSelectOption[string]{
 			{Label: "foo", IsSelected: true},
@@ -24,6 +26,7 @@ func runMultiSelect() {
 
 func TestMultiSelectInitialState(t *testing.T) {
 	go prompts.MultiSelect(prompts.MultiSelectParams[string]{
+		Input:   MockInput{},
 		Message: message,
 		Options: []*prompts.MultiSelectOption[string]{
 			{Label: "foo"},
@@ -40,6 +43,7 @@ func TestMultiSelectInitialState(t *testing.T) {
 
 func TestMultiSelectWithHint(t *testing.T) {
 	go prompts.MultiSelect(prompts.MultiSelectParams[string]{
+		Input:   MockInput{},
 		Message: message,
 		Options: []*prompts.MultiSelectOption[string]{
 			{Label: "foo", Hint: "hint-foo"},
@@ -56,6 +60,7 @@ func TestMultiSelectWithHint(t *testing.T) {
 
 func TestMultiSelectWithSelectedHint(t *testing.T) {
 	go prompts.MultiSelect(prompts.MultiSelectParams[string]{
+		Input:   MockInput{},
 		Message: message,
 		Options: []*prompts.MultiSelectOption[string]{
 			{Label: "foo", Hint: "hint-foo"},
@@ -94,8 +99,34 @@ func TestMultiSelectSubmitState(t *testing.T) {
 	cupaloy.SnapshotT(t, p.Frame)
 }
 
+func TestMultiSelectFormatValue(t *testing.T) {
+	go prompts.MultiSelect(prompts.MultiSelectParams[string]{
+		Input:   MockInput{},
+		Message: message,
+		Options: []*prompts.MultiSelectOption[string]{
+			{Label: "a", Value: "a"},
+			{Label: "b", Value: "b"},
+			{Label: "c", Value: "c"},
+		},
+		FormatValue: func(value []string) string {
+			return fmt.Sprintf("%d packages selected", len(value))
+		},
+	})
+	time.Sleep(time.Millisecond)
+
+	p := test.MultiSelectTestingPrompt.(*core.MultiSelectPrompt[string])
+	p.PressKey(&core.Key{Name: core.SpaceKey})
+	p.PressKey(&core.Key{Name: core.DownKey})
+	p.PressKey(&core.Key{Name: core.SpaceKey})
+	p.PressKey(&core.Key{Name: core.EnterKey})
+
+	assert.Equal(t, core.SubmitState, p.State)
+	assert.Contains(t, p.Frame, "2 packages selected")
+}
+
 func TestMultiSelectWithLongList(t *testing.T) {
 	go prompts.MultiSelect(prompts.MultiSelectParams[string]{
+		Input:   MockInput{},
 		Message: message,
 		Options: []*prompts.MultiSelectOption[string]{
 			{Label: "a"},
@@ -121,6 +152,7 @@ func TestMultiSelectWithLongList(t *testing.T) {
 
 func TestMultiSelectMultiValue(t *testing.T) {
 	go prompts.MultiSelect(prompts.MultiSelectParams[string]{
+		Input:   MockInput{},
 		Message: message,
 		Options: []*prompts.MultiSelectOption[string]{
 			{Label: "a", IsSelected: true},
@@ -142,6 +174,7 @@ func TestMultiSelectMultiValue(t *testing.T) {
 
 func TestMultiSelectEmptyFilter(t *testing.T) {
 	go prompts.MultiSelect(prompts.MultiSelectParams[string]{
+		Input:   MockInput{},
 		Message: message,
 		Filter:  true,
 		Options: []*prompts.MultiSelectOption[string]{
@@ -160,6 +193,7 @@ func TestMultiSelectEmptyFilter(t *testing.T) {
 
 func TestMultiSelectFilledFilter(t *testing.T) {
 	go prompts.MultiSelect(prompts.MultiSelectParams[string]{
+		Input:   MockInput{},
 		Message: message,
 		Filter:  true,
 		Options: []*prompts.MultiSelectOption[string]{
@@ -6,69 +6,88 @@ import (
 	"os"
 	"strings"
 
+	"github.com/orochaa/go-clack/core"
 	"github.com/orochaa/go-clack/core/utils"
 	"github.com/orochaa/go-clack/prompts/symbols"
+	"github.com/orochaa/go-clack/prompts/theme"
 	"github.com/orochaa/go-clack/third_party/picocolors"
 )
 
 type NoteOptions struct {
 	Title  string
 	Output io.Writer
+	Theme  theme.Theme
 }
 
 // Note displays a formatted note box with a title, message, and borders.
+// The box is joined to the bar of the theme (default: theme.Current()), or closed on its own if the theme has no visible bar.
 func Note(msg string, options NoteOptions) {
 	if options.Output == nil {
 		options.Output = os.Stdout
 	}
+	t := theme.Resolve(options.Theme)
 
 	lineLength := utils.StrLength(options.Title) + 7
 	for _, line := range utils.SplitLines(msg) {
 		lineLength = max(utils.StrLength(line)+4, lineLength)
 	}
 
-	frame := strings.Join([]string{
-		picocolors.Gray(symbols.BAR),
-		noteHeader(options.Title, lineLength),
-		noteBody(msg, lineLength),
-		noteFooter(lineLength),
+	lines := make([]string, 0, 5)
+	if bar := grayBar(t.Bar()); bar != "" {
+		lines = append(lines, bar)
+	}
+	lines = append(lines,
+		noteHeader(t, options.Title, lineLength),
+		noteBody(t, msg, lineLength),
+		noteFooter(t, lineLength),
 		"",
-	}, "\r\n")
+	)
+	frame := strings.Join(lines, "\r\n")
 
 	options.Output.Write([]byte(frame))
 }
 
-func noteHeader(title string, lineLength int) string {
+// noteLeftBorder returns the left border of the body of a note box, and its top and bottom left corners.
+func noteLeftBorder(t theme.Theme) (bar, top, bottom string) {
+	if strings.TrimSpace(t.Bar()) != "" {
+		return t.Bar(), symbols.CONNECT_LEFT, symbols.CONNECT_LEFT
+	}
+	return symbols.BAR, symbols.CORNER_TOP_LEFT, symbols.CORNER_BOTTOM_LEFT
+}
+
+func noteHeader(t theme.Theme, title string, lineLength int) string {
 	if title == "" {
-		left := symbols.CONNECT_LEFT
+		_, left, _ := noteLeftBorder(t)
 		top := strings.Repeat(symbols.BAR_H, lineLength)
 		right := symbols.CORNER_TOP_RIGHT
 		return picocolors.Gray(fmt.Sprint(left, top, right))
 	}
 
-	left := picocolors.Green(symbols.STEP_SUBMIT)
+	left := t.SymbolColor(core.SubmitState)(t.Symbol(core.SubmitState))
 	topLength := max(lineLength-utils.StrLength(title)-2, 0)
 	top := picocolors.Gray(strings.Repeat(symbols.BAR_H, topLength))
 	right := picocolors.Gray(symbols.CORNER_TOP_RIGHT)
 	return fmt.Sprintf("%s %s %s%s", left, title, top, right)
 }
 
-func noteBody(msg string, lineLength int) string {
-	bar := picocolors.Gray(symbols.BAR)
+func noteBody(t theme.Theme, msg string, lineLength int) string {
+	left, _, _ := noteLeftBorder(t)
+	left = picocolors.Gray(left)
+	right := picocolors.Gray(symbols.BAR)
 
 	lines := utils.SplitLines("\r\n" + msg + "\r\n")
 	body := make([]string, len(lines))
 
 	for i, line := range lines {
 		whitespace := strings.Repeat(" ", max(lineLength-2-utils.StrLength(line), 1))
-		body[i] = fmt.Sprintf("%s  %s%s%s", bar, line, whitespace, bar)
+		body[i] = fmt.Sprintf("%s  %s%s%s", left, line, whitespace, right)
 	}
 
 	return strings.Join(body, "\r\n")
 }
 
-func noteFooter(lineLength int) string {
-	left := symbols.CONNECT_LEFT
+func noteFooter(t theme.Theme, lineLength int) string {
+	_, _, left := noteLeftBorder(t)
 	bottom := strings.Repeat(symbols.BAR_H, lineLength)
 	right := symbols.CORNER_BOTTOM_RIGHT
 
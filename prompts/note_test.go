@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/orochaa/go-clack/prompts"
+	"github.com/orochaa/go-clack/prompts/theme"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -86,3 +87,32 @@ func TestNoteTitleWithMultiline(t *testing.T) {
 		"",
 	}, "\r\n"), writer.Data[0])
 }
+
+func TestNoteBoxWithTheme(t *testing.T) {
+	writer := &MockWriter{}
+	prompts.Note("test", prompts.NoteOptions{Title: "title", Output: writer, Theme: theme.MinimalTheme{}})
+
+	assert.Equal(t, strings.Join([]string{
+		" ",
+		"✔ title ─────╮",
+		"│            │",
+		"│  test      │",
+		"│            │",
+		"╰────────────╯",
+		"",
+	}, "\r\n"), writer.Data[0])
+}
+
+func TestNoteBoxWithCompactTheme(t *testing.T) {
+	writer := &MockWriter{}
+	prompts.Note("test", prompts.NoteOptions{Output: writer, Theme: theme.CompactTheme{}})
+
+	assert.Equal(t, strings.Join([]string{
+		"╭────────╮",
+		"│        │",
+		"│  test  │",
+		"│        │",
+		"╰────────╯",
+		"",
+	}, "\r\n"), writer.Data[0])
+}
@@ -2,7 +2,8 @@ package prompts
 
 import (
 	"context"
-	"os"
+	"io"
+	"time"
 
 	"github.com/orochaa/go-clack/core"
 	"github.com/orochaa/go-clack/prompts/test"
@@ -10,13 +11,23 @@ import (
 )
 
 type PasswordParams struct {
-	Context      context.Context
-	Input        *os.File
-	Output       *os.File
-	Message      string
-	InitialValue string
-	Required     bool
-	Validate     func(value string) error
+	Context           context.Context
+	Input             io.Reader
+	Output            io.Writer
+	Message           string
+	InitialValue      string
+	Required          bool
+	Validate          func(value string) error
+	LiveValidate      func(ctx context.Context, value string) error
+	LiveValidateDelay time.Duration
+	Timeout           time.Duration
+	TimeoutCancel     bool
+	Ephemeral         bool
+	LineMode          bool
+	Summary           func(value string) string
+	Settings          *core.SettingsOptions
+	Theme             theme.Theme
+	FormatValue       func(value string) string
 }
 
 // Password displays a password input prompt to the user.
@@ -30,29 +41,48 @@ type PasswordParams struct {
 //
 // Parameters:
 //   - Context (context.Context): The context for the prompt (default: context.Background).
-//   - Input (*os.File): The input stream for the prompt (default: OSFileSystem).
-//   - Output (*os.File): The output stream for the prompt (default: OSFileSystem).
+//   - Input (io.Reader): The input stream for the prompt (default: os.Stdin).
+//   - Output (io.Writer): The output stream for the prompt (default: os.Stdout).
 //   - Message (string): The message to display to the user (default: "").
 //   - InitialValue (string): The initial value of the password input (default: "").
 //   - Required (bool): Whether the password input is required (default: false).
 //   - Validate (func(value string) error): Custom validation function for the password (default: nil).
+//   - LiveValidate (func(ctx context.Context, value string) error): Validation function run as the user types, whose errors are shown inline (default: nil).
+//   - LiveValidateDelay (time.Duration): The time waited after the last keystroke before validating live (default: core.DefaultLiveValidateDelay).
+//   - Timeout (time.Duration): The time after which the current value is submitted, unless the user presses a key before (default: 0, no timeout).
+//   - TimeoutCancel (bool): Whether to cancel the prompt with core.ErrTimeout instead of submitting the current value on timeout (default: false).
+//   - Ephemeral (bool): Whether to erase the prompt once submitted, or replace it by its Summary (default: false).
+//   - LineMode (bool): Whether to read whole lines as answers, without raw mode or cursor movements, as done when the input is not a terminal (default: false).
+//   - Summary (func(value string) string): The one-line summary of the submitted value of an ephemeral prompt (default: nil, the prompt is erased).
+//   - Settings (*core.SettingsOptions): The key bindings and options of the prompt (default: core.Settings).
+//   - Theme (theme.Theme): The look of the prompt (default: theme.Current()).
+//   - FormatValue (func(value string) string): Formats the value displayed once the prompt is submitted or cancelled (default: nil).
 //
 // Returns:
 //   - string: The password without the mask.
 //   - error: An error if the user cancels the prompt or if an error occurs.
 func Password(params PasswordParams) (string, error) {
 	p := core.NewPasswordPrompt(core.PasswordPromptParams{
-		Context:      params.Context,
-		Input:        params.Input,
-		Output:       params.Output,
-		InitialValue: params.InitialValue,
-		Required:     params.Required,
-		Validate:     params.Validate,
+		Context:           params.Context,
+		Input:             params.Input,
+		Output:            params.Output,
+		InitialValue:      params.InitialValue,
+		Required:          params.Required,
+		Validate:          params.Validate,
+		LiveValidate:      params.LiveValidate,
+		LiveValidateDelay: params.LiveValidateDelay,
+		Timeout:           params.Timeout,
+		TimeoutCancel:     params.TimeoutCancel,
+		Ephemeral:         params.Ephemeral,
+		LineMode:          params.LineMode,
+		Summary:           params.Summary,
+		Settings:          params.Settings,
 		Render: func(p *core.PasswordPrompt) string {
 			return theme.ApplyTheme(theme.ThemeParams[string]{
 				Context:         p.Prompt,
+				Theme:           params.Theme,
 				Message:         params.Message,
-				Value:           p.ValueWithMask(),
+				Value:           formatValue(p.State, p.Value, params.FormatValue, p.ValueWithMask()),
 				ValueWithCursor: p.ValueWithMaskAndCursor(),
 			})
 		},
@@ -14,7 +14,7 @@ import (
 )
 
 func TestPasswordInitialState(t *testing.T) {
-	go prompts.Password(prompts.PasswordParams{Message: message})
+	go prompts.Password(prompts.PasswordParams{Input: MockInput{}, Message: message})
 	time.Sleep(time.Millisecond)
 
 	p := test.PasswordTestingPrompt
@@ -26,7 +26,7 @@ func TestPasswordInitialState(t *testing.T) {
 }
 
 func TestPasswordInitialStateWithInitialValue(t *testing.T) {
-	go prompts.Password(prompts.PasswordParams{Message: message, InitialValue: "foo"})
+	go prompts.Password(prompts.PasswordParams{Input: MockInput{}, Message: message, InitialValue: "foo"})
 	time.Sleep(time.Millisecond)
 
 	p := test.PasswordTestingPrompt
@@ -38,7 +38,7 @@ func TestPasswordInitialStateWithInitialValue(t *testing.T) {
 }
 
 func TestPasswordErrorState(t *testing.T) {
-	go prompts.Password(prompts.PasswordParams{Message: message, InitialValue: "foo", Validate: func(value string) error {
+	go prompts.Password(prompts.PasswordParams{Input: MockInput{}, Message: message, InitialValue: "foo", Validate: func(value string) error {
 		return fmt.Errorf("invalid value: %s", value)
 	}})
 	time.Sleep(time.Millisecond)
@@ -55,7 +55,7 @@ func TestPasswordErrorState(t *testing.T) {
 }
 
 func TestPasswordCancelState(t *testing.T) {
-	go prompts.Password(prompts.PasswordParams{Message: message})
+	go prompts.Password(prompts.PasswordParams{Input: MockInput{}, Message: message})
 	time.Sleep(time.Millisecond)
 
 	p := test.PasswordTestingPrompt
@@ -69,7 +69,7 @@ func TestPasswordCancelState(t *testing.T) {
 }
 
 func TestPasswordCancelStateWithValue(t *testing.T) {
-	go prompts.Password(prompts.PasswordParams{Message: message, InitialValue: "foo"})
+	go prompts.Password(prompts.PasswordParams{Input: MockInput{}, Message: message, InitialValue: "foo"})
 	time.Sleep(time.Millisecond)
 
 	p := test.PasswordTestingPrompt
@@ -83,7 +83,7 @@ func TestPasswordCancelStateWithValue(t *testing.T) {
 }
 
 func TestPasswordSubmitState(t *testing.T) {
-	go prompts.Password(prompts.PasswordParams{Message: message, InitialValue: "foo"})
+	go prompts.Password(prompts.PasswordParams{Input: MockInput{}, Message: message, InitialValue: "foo"})
 	time.Sleep(time.Millisecond)
 
 	p := test.PasswordTestingPrompt
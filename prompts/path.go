@@ -2,23 +2,33 @@ package prompts
 
 import (
 	"context"
-	"os"
+	"io"
+	"time"
 
 	"github.com/orochaa/go-clack/core"
 	"github.com/orochaa/go-clack/prompts/test"
 	"github.com/orochaa/go-clack/prompts/theme"
-	"github.com/orochaa/go-clack/third_party/picocolors"
 )
 
 type PathParams struct {
-	Context      context.Context
-	Input        *os.File
-	Output       *os.File
-	Message      string
-	InitialValue string
-	OnlyShowDir  bool
-	Required     bool
-	Validate     func(value string) error
+	Context           context.Context
+	Input             io.Reader
+	Output            io.Writer
+	Message           string
+	InitialValue      string
+	OnlyShowDir       bool
+	Required          bool
+	Validate          func(value string) error
+	LiveValidate      func(ctx context.Context, value string) error
+	LiveValidateDelay time.Duration
+	Timeout           time.Duration
+	TimeoutCancel     bool
+	Ephemeral         bool
+	LineMode          bool
+	Summary           func(value string) string
+	Settings          *core.SettingsOptions
+	Theme             theme.Theme
+	FormatValue       func(value string) string
 }
 
 // Path displays a input prompt to the user.
@@ -32,37 +42,56 @@ type PathParams struct {
 //
 // Parameters:
 //   - Context (context.Context): The context for the prompt (default: context.Background).
-//   - Input (*os.File): The input stream for the prompt (default: OSFileSystem).
-//   - Output (*os.File): The output stream for the prompt (default: OSFileSystem).
+//   - Input (io.Reader): The input stream for the prompt (default: os.Stdin).
+//   - Output (io.Writer): The output stream for the prompt (default: os.Stdout).
 //   - Message (string): The message to display to the user (default: "").
 //   - InitialValue (string): The initial value of the path input (default: current working directory).
 //   - OnlyShowDir (bool): Whether to only show directories (default: false).
 //   - Required (bool): Whether the path input is required (default: false).
 //   - FileSystem (FileSystem): The file system implementation to use (default: OSFileSystem).
 //   - Validate (func(value string) error): Custom validation function for the path (default: nil).
+//   - LiveValidate (func(ctx context.Context, value string) error): Validation function run as the user types, whose errors are shown inline (default: nil).
+//   - LiveValidateDelay (time.Duration): The time waited after the last keystroke before validating live (default: core.DefaultLiveValidateDelay).
+//   - Timeout (time.Duration): The time after which the current value is submitted, unless the user presses a key before (default: 0, no timeout).
+//   - TimeoutCancel (bool): Whether to cancel the prompt with core.ErrTimeout instead of submitting the current value on timeout (default: false).
+//   - Ephemeral (bool): Whether to erase the prompt once submitted, or replace it by its Summary (default: false).
+//   - LineMode (bool): Whether to read whole lines as answers, without raw mode or cursor movements, as done when the input is not a terminal (default: false).
+//   - Summary (func(value string) string): The one-line summary of the submitted value of an ephemeral prompt (default: nil, the prompt is erased).
+//   - Settings (*core.SettingsOptions): The key bindings and options of the prompt (default: core.Settings).
+//   - Theme (theme.Theme): The look of the prompt (default: theme.Current()).
+//   - FormatValue (func(value string) string): Formats the value displayed once the prompt is submitted or cancelled (default: nil).
 //
 // Returns:
 //   - string: The path value.
 //   - error: An error if the user cancels the prompt or if an error occurs.
 func Path(params PathParams) (string, error) {
 	p := core.NewPathPrompt(core.PathPromptParams{
-		Context:      params.Context,
-		Input:        params.Input,
-		Output:       params.Output,
-		InitialValue: params.InitialValue,
-		OnlyShowDir:  params.OnlyShowDir,
-		Required:     params.Required,
-		Validate:     params.Validate,
+		Context:           params.Context,
+		Input:             params.Input,
+		Output:            params.Output,
+		InitialValue:      params.InitialValue,
+		OnlyShowDir:       params.OnlyShowDir,
+		Required:          params.Required,
+		Validate:          params.Validate,
+		LiveValidate:      params.LiveValidate,
+		LiveValidateDelay: params.LiveValidateDelay,
+		Timeout:           params.Timeout,
+		TimeoutCancel:     params.TimeoutCancel,
+		Ephemeral:         params.Ephemeral,
+		LineMode:          params.LineMode,
+		Summary:           params.Summary,
+		Settings:          params.Settings,
 		Render: func(p *core.PathPrompt) string {
+			t := theme.Resolve(params.Theme)
 			valueWithCursor := p.ValueWithCursor()
 
 			if len(p.HintOptions) > 0 {
 				var hintOptions string
 				for i, hintOption := range p.HintOptions {
 					if i == p.HintIndex {
-						hintOptions += picocolors.Cyan(hintOption)
+						hintOptions += t.Highlight(hintOption)
 					} else {
-						hintOptions += picocolors.Dim(hintOption)
+						hintOptions += t.Option(hintOption, false)
 					}
 					if i+1 < len(p.HintOptions) {
 						hintOptions += " "
@@ -73,8 +102,9 @@ func Path(params PathParams) (string, error) {
 
 			return theme.ApplyTheme(theme.ThemeParams[string]{
 				Context:         p.Prompt,
+				Theme:           t,
 				Message:         params.Message,
-				Value:           p.Value,
+				Value:           formatValue(p.State, p.Value, params.FormatValue, p.Value),
 				ValueWithCursor: valueWithCursor,
 			})
 		},
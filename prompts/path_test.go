@@ -2,6 +2,7 @@ package prompts_test
 
 import (
 	"fmt"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
@@ -14,7 +15,7 @@ import (
 )
 
 func TestPathInitialState(t *testing.T) {
-	go prompts.Path(prompts.PathParams{Message: message})
+	go prompts.Path(prompts.PathParams{Input: MockInput{}, Message: message})
 	time.Sleep(time.Millisecond)
 
 	p := test.PathTestingPrompt
@@ -26,7 +27,7 @@ func TestPathInitialState(t *testing.T) {
 }
 
 func TestPathInitialStateWithInitialValue(t *testing.T) {
-	go prompts.Path(prompts.PathParams{Message: message, InitialValue: "/foo"})
+	go prompts.Path(prompts.PathParams{Input: MockInput{}, Message: message, InitialValue: "/foo"})
 	time.Sleep(time.Millisecond)
 
 	p := test.PathTestingPrompt
@@ -38,7 +39,7 @@ func TestPathInitialStateWithInitialValue(t *testing.T) {
 }
 
 func TestPathErrorState(t *testing.T) {
-	go prompts.Path(prompts.PathParams{Message: message, InitialValue: "/foo", Validate: func(value string) error {
+	go prompts.Path(prompts.PathParams{Input: MockInput{}, Message: message, InitialValue: "/foo", Validate: func(value string) error {
 		return fmt.Errorf("invalid value: %s", value)
 	}})
 	time.Sleep(time.Millisecond)
@@ -55,7 +56,7 @@ func TestPathErrorState(t *testing.T) {
 }
 
 func TestPathCancelState(t *testing.T) {
-	go prompts.Path(prompts.PathParams{Message: message})
+	go prompts.Path(prompts.PathParams{Input: MockInput{}, Message: message})
 	time.Sleep(time.Millisecond)
 
 	p := test.PathTestingPrompt
@@ -70,7 +71,7 @@ func TestPathCancelState(t *testing.T) {
 }
 
 func TestPathCancelStateWithValue(t *testing.T) {
-	go prompts.Path(prompts.PathParams{Message: message})
+	go prompts.Path(prompts.PathParams{Input: MockInput{}, Message: message})
 	time.Sleep(time.Millisecond)
 
 	p := test.PathTestingPrompt
@@ -84,7 +85,7 @@ func TestPathCancelStateWithValue(t *testing.T) {
 }
 
 func TestPathSubmitState(t *testing.T) {
-	go prompts.Path(prompts.PathParams{Message: message})
+	go prompts.Path(prompts.PathParams{Input: MockInput{}, Message: message})
 	time.Sleep(time.Millisecond)
 
 	p := test.PathTestingPrompt
@@ -97,8 +98,26 @@ func TestPathSubmitState(t *testing.T) {
 	assert.Equal(t, expected, p.Frame)
 }
 
+func TestPathFormatValue(t *testing.T) {
+	go prompts.Path(prompts.PathParams{
+		Input:       MockInput{},
+		Message:     message,
+		FormatValue: func(value string) string { return filepath.Base(value) },
+	})
+	time.Sleep(time.Millisecond)
+
+	p := test.PathTestingPrompt
+	p.PressKey(&core.Key{Name: core.EnterKey})
+
+	title := symbols.State(core.SubmitState) + " " + message
+	value := symbols.BAR + " " + filepath.Base(p.Value)
+	expected := strings.Join([]string{symbols.BAR, title, value}, "\r\n")
+	assert.Equal(t, core.SubmitState, p.State)
+	assert.Equal(t, expected, p.Frame)
+}
+
 func TestPathValueWithOptions(t *testing.T) {
-	go prompts.Path(prompts.PathParams{Message: message})
+	go prompts.Path(prompts.PathParams{Input: MockInput{}, Message: message})
 	time.Sleep(time.Millisecond)
 
 	p := test.PathTestingPrompt
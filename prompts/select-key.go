@@ -3,13 +3,13 @@ package prompts
 import (
 	"context"
 	"fmt"
-	"os"
+	"io"
+	"time"
 
 	"github.com/orochaa/go-clack/core"
 	"github.com/orochaa/go-clack/core/validator"
 	"github.com/orochaa/go-clack/prompts/test"
 	"github.com/orochaa/go-clack/prompts/theme"
-	"github.com/orochaa/go-clack/third_party/picocolors"
 )
 
 type SelectKeyOption[TValue comparable] struct {
@@ -19,11 +19,19 @@ type SelectKeyOption[TValue comparable] struct {
 }
 
 type SelectKeyParams[TValue comparable] struct {
-	Context context.Context
-	Input   *os.File
-	Output  *os.File
-	Message string
-	Options []SelectKeyOption[TValue]
+	Context       context.Context
+	Input         io.Reader
+	Output        io.Writer
+	Message       string
+	Options       []SelectKeyOption[TValue]
+	Timeout       time.Duration
+	TimeoutCancel bool
+	Ephemeral     bool
+	LineMode      bool
+	Summary       func(value TValue) string
+	Settings      *core.SettingsOptions
+	Theme         theme.Theme
+	FormatValue   func(value TValue) string
 }
 
 // SelectKey displays a select-key prompt to the user.
@@ -36,10 +44,18 @@ type SelectKeyParams[TValue comparable] struct {
 //
 // Parameters:
 //   - Context (context.Context): The context for the prompt (default: context.Background).
-//   - Input (*os.File): The input stream for the prompt (default: OSFileSystem).
-//   - Output (*os.File): The output stream for the prompt (default: OSFileSystem).
+//   - Input (io.Reader): The input stream for the prompt (default: os.Stdin).
+//   - Output (io.Writer): The output stream for the prompt (default: os.Stdout).
 //   - Message (string): The message to display to the user (default: "").
 //   - Options ([]*SelectKeyOption[TValue]): A list of options for the prompt (default: nil).
+//   - Timeout (time.Duration): The time after which the current value is submitted, unless the user presses a key before (default: 0, no timeout).
+//   - TimeoutCancel (bool): Whether to cancel the prompt with core.ErrTimeout instead of submitting the current value on timeout (default: false).
+//   - Ephemeral (bool): Whether to erase the prompt once submitted, or replace it by its Summary (default: false).
+//   - LineMode (bool): Whether to read whole lines as answers, without raw mode or cursor movements, as done when the input is not a terminal (default: false).
+//   - Summary (func(value TValue) string): The one-line summary of the submitted value of an ephemeral prompt (default: nil, the prompt is erased).
+//   - Settings (*core.SettingsOptions): The key bindings and options of the prompt (default: core.Settings).
+//   - Theme (theme.Theme): The look of the prompt (default: theme.Current()).
+//   - FormatValue (func(value TValue) string): Formats the value displayed once the prompt is submitted or cancelled (default: nil).
 //
 // Returns:
 //   - TValue: The value of the selected option.
@@ -58,18 +74,25 @@ func SelectKey[TValue comparable](params SelectKeyParams[TValue]) (TValue, error
 	}
 
 	p := core.NewSelectKeyPrompt(core.SelectKeyPromptParams[TValue]{
-		Context: params.Context,
-		Input:   params.Input,
-		Output:  params.Output,
-		Options: options,
+		Context:       params.Context,
+		Input:         params.Input,
+		Output:        params.Output,
+		Options:       options,
+		Timeout:       params.Timeout,
+		TimeoutCancel: params.TimeoutCancel,
+		Ephemeral:     params.Ephemeral,
+		LineMode:      params.LineMode,
+		Summary:       params.Summary,
+		Settings:      params.Settings,
 		Render: func(p *core.SelectKeyPrompt[TValue]) string {
+			t := theme.Resolve(params.Theme)
 			var value string
 			switch p.State {
 			case core.SubmitState, core.CancelState:
 			default:
 				keyOptions := make([]string, len(params.Options))
 				for i, option := range params.Options {
-					key := picocolors.Cyan("[" + option.Key + "]")
+					key := t.Highlight("[" + option.Key + "]")
 					label := option.Label
 					keyOptions[i] = fmt.Sprintf("%s %s", key, label)
 				}
@@ -78,9 +101,13 @@ func SelectKey[TValue comparable](params SelectKeyParams[TValue]) (TValue, error
 
 			return theme.ApplyTheme(theme.ThemeParams[TValue]{
 				Context:         p.Prompt,
+				Theme:           t,
 				Message:         params.Message,
-				Value:           params.Options[p.CursorIndex].Label,
+				Value:           formatValue(p.State, p.Value, params.FormatValue, params.Options[p.CursorIndex].Label),
 				ValueWithCursor: value,
+				Options: lineOptions(p.LineMode, p.Options, func(option *core.SelectKeyOption[TValue]) string {
+					return fmt.Sprintf("[%s] %s", option.Key, option.Label)
+				}),
 			})
 		},
 	})
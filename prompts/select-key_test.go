@@ -13,6 +13,7 @@ import (
 
 func runSelectKey() {
 	prompts.SelectKey(prompts.SelectKeyParams[string]{
+		Input:   MockInput{},
 		Message: message,
 		Options: []prompts.SelectKeyOption[string]{
 			{Key: "f", Label: "Foo"},
@@ -52,3 +53,12 @@ func TestSelectKeySubmitState(t *testing.T) {
 	assert.Equal(t, core.SubmitState, p.State)
 	cupaloy.SnapshotT(t, p.Frame)
 }
+
+func TestSelectKeyLineMode(t *testing.T) {
+	go runSelectKey()
+	time.Sleep(time.Millisecond)
+	p := test.SelectKeyTestingPrompt.(*core.SelectKeyPrompt[string])
+	p.LineMode = true
+
+	assert.Equal(t, message+"\n  1. [f] Foo\n  2. [b] Bar\n  3. [Enter] Baz\n> ", p.Render(&p.Prompt))
+}
@@ -3,27 +3,35 @@ package prompts
 import (
 	"context"
 	"fmt"
-	"os"
+	"io"
 	"strings"
+	"time"
 
 	"github.com/orochaa/go-clack/core"
-	"github.com/orochaa/go-clack/prompts/symbols"
 	"github.com/orochaa/go-clack/prompts/test"
 	"github.com/orochaa/go-clack/prompts/theme"
-	"github.com/orochaa/go-clack/third_party/picocolors"
 )
 
 type FileSystem = core.FileSystem
 
 type SelectPathParams struct {
-	Context      context.Context
-	Input        *os.File
-	Output       *os.File
-	Message      string
-	InitialValue string
-	OnlyShowDir  bool
-	Filter       bool
-	FileSystem   FileSystem
+	Context       context.Context
+	Input         io.Reader
+	Output        io.Writer
+	Message       string
+	InitialValue  string
+	OnlyShowDir   bool
+	Filter        bool
+	FileSystem    FileSystem
+	Mouse         bool
+	Timeout       time.Duration
+	TimeoutCancel bool
+	Ephemeral     bool
+	LineMode      bool
+	Summary       func(value string) string
+	Settings      *core.SettingsOptions
+	Theme         theme.Theme
+	FormatValue   func(value string) string
 }
 
 // SelectPath displays a select prompt to the user.
@@ -37,27 +45,44 @@ type SelectPathParams struct {
 //
 // Parameters:
 //   - Context (context.Context): The context for the prompt (default: context.Background).
-//   - Input (*os.File): The input stream for the prompt (default: OSFileSystem).
-//   - Output (*os.File): The output stream for the prompt (default: OSFileSystem).
+//   - Input (io.Reader): The input stream for the prompt (default: os.Stdin).
+//   - Output (io.Writer): The output stream for the prompt (default: os.Stdout).
 //   - Message (string): The message to display to the user (default: "").
 //   - InitialValue (string): The initial path value (default: current working directory).
 //   - OnlyShowDir (bool): Whether to only show directories (default: false).
 //   - Filter (bool): Whether to enable filtering of options (default: false).
 //   - FileSystem (FileSystem): The file system implementation to use (default: OSFileSystem).
+//   - Mouse (bool): Whether to select options by clicking and scroll them with the wheel (default: core.Settings.Mouse).
+//   - Timeout (time.Duration): The time after which the current value is submitted, unless the user presses a key before (default: 0, no timeout).
+//   - TimeoutCancel (bool): Whether to cancel the prompt with core.ErrTimeout instead of submitting the current value on timeout (default: false).
+//   - Ephemeral (bool): Whether to erase the prompt once submitted, or replace it by its Summary (default: false).
+//   - LineMode (bool): Whether to read whole lines as answers, without raw mode or cursor movements, as done when the input is not a terminal (default: false).
+//   - Summary (func(value string) string): The one-line summary of the submitted value of an ephemeral prompt (default: nil, the prompt is erased).
+//   - Settings (*core.SettingsOptions): The key bindings and options of the prompt (default: core.Settings).
+//   - Theme (theme.Theme): The look of the prompt (default: theme.Current()).
+//   - FormatValue (func(value string) string): Formats the value displayed once the prompt is submitted or cancelled (default: nil).
 //
 // Returns:
 //   - string: The path of the selected option.
 //   - error: An error if the user cancels the prompt or if an error occurs.
 func SelectPath(params SelectPathParams) (string, error) {
 	p := core.NewSelectPathPrompt(core.SelectPathPromptParams{
-		Context:      params.Context,
-		Input:        params.Input,
-		Output:       params.Output,
-		InitialValue: params.InitialValue,
-		OnlyShowDir:  params.OnlyShowDir,
-		Filter:       params.Filter,
-		FileSystem:   params.FileSystem,
+		Context:       params.Context,
+		Input:         params.Input,
+		Output:        params.Output,
+		InitialValue:  params.InitialValue,
+		OnlyShowDir:   params.OnlyShowDir,
+		Filter:        params.Filter,
+		FileSystem:    params.FileSystem,
+		Mouse:         params.Mouse,
+		Timeout:       params.Timeout,
+		TimeoutCancel: params.TimeoutCancel,
+		Ephemeral:     params.Ephemeral,
+		LineMode:      params.LineMode,
+		Summary:       params.Summary,
+		Settings:      params.Settings,
 		Render: func(p *core.SelectPathPrompt) string {
+			t := theme.Resolve(params.Theme)
 			message := params.Message
 			var value string
 
@@ -73,21 +98,19 @@ func SelectPath(params SelectPathParams) (string, error) {
 					} else if option.IsDir {
 						dir = ">"
 					}
-					if option.IsEqual(p.CurrentOption) {
-						radio = picocolors.Green(symbols.RADIO_ACTIVE)
-						label = option.Name
-					} else {
-						radio = picocolors.Dim(symbols.RADIO_INACTIVE)
-						label = picocolors.Dim(option.Name)
-						dir = picocolors.Dim(dir)
+					isActive := option.IsEqual(p.CurrentOption)
+					radio = t.Radio(isActive)
+					label = t.Option(option.Name, isActive)
+					if !isActive {
+						dir = t.Option(dir, false)
 					}
 					depth := strings.Repeat(" ", option.Depth)
 					radioOptions[i] = fmt.Sprintf("%s%s %s %s", depth, radio, label, dir)
 				}
 
-				if p.Filter {
+				if p.Filter && !p.LineMode {
 					if p.Search == "" {
-						message = fmt.Sprintf("%s\n> %s", message, picocolors.Inverse("T")+picocolors.Dim("ype to filter..."))
+						message = fmt.Sprintf("%s\n> %s", message, t.Placeholder("Type to filter...", true))
 					} else {
 						message = fmt.Sprintf("%s\n> %s", message, p.Search+"█")
 					}
@@ -101,8 +124,9 @@ func SelectPath(params SelectPathParams) (string, error) {
 
 			return theme.ApplyTheme(theme.ThemeParams[string]{
 				Context:         p.Prompt,
+				Theme:           t,
 				Message:         message,
-				Value:           p.Value,
+				Value:           formatValue(p.State, p.Value, params.FormatValue, p.Value),
 				ValueWithCursor: value,
 			})
 		},
@@ -13,6 +13,7 @@ import (
 
 func runSelectPath() {
 	prompts.SelectPath(prompts.SelectPathParams{
+		Input:      MockInput{},
 		Message:    message,
 		FileSystem: (prompts.FileSystem)(MockFileSystem{}),
 	})
@@ -62,6 +63,7 @@ func TestSelectPathSubmitState(t *testing.T) {
 
 func TestSelectPathEmptyFilter(t *testing.T) {
 	go prompts.SelectPath(prompts.SelectPathParams{
+		Input:      MockInput{},
 		Message:    message,
 		FileSystem: (prompts.FileSystem)(MockFileSystem{}),
 		Filter:     true,
@@ -76,6 +78,7 @@ func TestSelectPathEmptyFilter(t *testing.T) {
 
 func TestSelectPathFilledFilter(t *testing.T) {
 	go prompts.SelectPath(prompts.SelectPathParams{
+		Input:      MockInput{},
 		Message:    message,
 		FileSystem: (prompts.FileSystem)(MockFileSystem{}),
 		Filter:     true,
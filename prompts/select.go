@@ -3,14 +3,13 @@ package prompts
 import (
 	"context"
 	"fmt"
-	"os"
+	"io"
+	"time"
 
 	"github.com/orochaa/go-clack/core"
 	"github.com/orochaa/go-clack/core/validator"
-	"github.com/orochaa/go-clack/prompts/symbols"
 	"github.com/orochaa/go-clack/prompts/test"
 	"github.com/orochaa/go-clack/prompts/theme"
-	"github.com/orochaa/go-clack/third_party/picocolors"
 )
 
 type SelectOption[TValue comparable] struct {
@@ -20,14 +19,24 @@ type SelectOption[TValue comparable] struct {
 }
 
 type SelectParams[TValue comparable] struct {
-	Context      context.Context
-	Input        *os.File
-	Output       *os.File
-	Message      string
-	InitialValue TValue
-	Options      []*SelectOption[TValue]
-	Filter       bool
-	Required     bool
+	Context          context.Context
+	Input            io.Reader
+	Output           io.Writer
+	Message          string
+	InitialValue     TValue
+	Options          []*SelectOption[TValue]
+	Filter           bool
+	Required         bool
+	Mouse            bool
+	Timeout          time.Duration
+	TimeoutCancel    bool
+	TypeAheadTimeout time.Duration
+	Ephemeral        bool
+	LineMode         bool
+	Summary          func(value TValue) string
+	Settings         *core.SettingsOptions
+	Theme            theme.Theme
+	FormatValue      func(value TValue) string
 }
 
 // Select displays a select prompt to the user.
@@ -41,13 +50,23 @@ type SelectParams[TValue comparable] struct {
 //
 // Parameters:
 //   - Context (context.Context): The context for the prompt (default: context.Background).
-//   - Input (*os.File): The input stream for the prompt (default: OSFileSystem).
-//   - Output (*os.File): The output stream for the prompt (default: OSFileSystem).
+//   - Input (io.Reader): The input stream for the prompt (default: os.Stdin).
+//   - Output (io.Writer): The output stream for the prompt (default: os.Stdout).
 //   - Message (string): The message to display to the user (default: "").
 //   - InitialValue (TValue): The initial value of the prompt (default: zero value of TValue).
 //   - Options ([]*SelectOption[TValue]): A list of options for the prompt (default: nil).
 //   - Filter (bool): Whether to enable filtering of options (default: false).
 //   - Required (bool): Whether the prompt requires a selection (default: false).
+//   - Mouse (bool): Whether to select options by clicking and scroll them with the wheel (default: core.Settings.Mouse).
+//   - Timeout (time.Duration): The time after which the current value is submitted, unless the user presses a key before (default: 0, no timeout).
+//   - TimeoutCancel (bool): Whether to cancel the prompt with core.ErrTimeout instead of submitting the current value on timeout (default: false).
+//   - TypeAheadTimeout (time.Duration): The time after which the typed prefix is reset if no other key is typed (default: core.DefaultTypeAheadTimeout).
+//   - Ephemeral (bool): Whether to erase the prompt once submitted, or replace it by its Summary (default: false).
+//   - LineMode (bool): Whether to read whole lines as answers, without raw mode or cursor movements, as done when the input is not a terminal (default: false).
+//   - Summary (func(value TValue) string): The one-line summary of the submitted value of an ephemeral prompt (default: nil, the prompt is erased).
+//   - Settings (*core.SettingsOptions): The key bindings and options of the prompt (default: core.Settings).
+//   - Theme (theme.Theme): The look of the prompt (default: theme.Current()).
+//   - FormatValue (func(value TValue) string): Formats the value displayed once the prompt is submitted or cancelled (default: nil).
 //
 // Returns:
 //   - TValue: The value of the selected option.
@@ -65,14 +84,23 @@ func Select[TValue comparable](params SelectParams[TValue]) (TValue, error) {
 	}
 
 	p := core.NewSelectPrompt(core.SelectPromptParams[TValue]{
-		Context:      params.Context,
-		Input:        params.Input,
-		Output:       params.Output,
-		InitialValue: params.InitialValue,
-		Options:      options,
-		Filter:       params.Filter,
-		Required:     params.Required,
+		Context:          params.Context,
+		Input:            params.Input,
+		Output:           params.Output,
+		InitialValue:     params.InitialValue,
+		Options:          options,
+		Filter:           params.Filter,
+		Required:         params.Required,
+		Mouse:            params.Mouse,
+		Timeout:          params.Timeout,
+		TimeoutCancel:    params.TimeoutCancel,
+		TypeAheadTimeout: params.TypeAheadTimeout,
+		Ephemeral:        params.Ephemeral,
+		LineMode:         params.LineMode,
+		Summary:          params.Summary,
+		Settings:         params.Settings,
 		Render: func(p *core.SelectPrompt[TValue]) string {
+			t := theme.Resolve(params.Theme)
 			message := params.Message
 			var value string
 
@@ -89,18 +117,12 @@ func Select[TValue comparable](params SelectParams[TValue]) (TValue, error) {
 							continue
 						}
 
-						if i == p.CursorIndex && option.Hint != "" {
-							radio := picocolors.Green(symbols.RADIO_ACTIVE)
-							label := option.Label
-							hint := picocolors.Dim("(" + option.Hint + ")")
-							radioOptions[i] = fmt.Sprintf("%s %s %s", radio, label, hint)
-						} else if i == p.CursorIndex {
-							radio := picocolors.Green(symbols.RADIO_ACTIVE)
-							label := option.Label
-							radioOptions[i] = fmt.Sprintf("%s %s", radio, label)
+						isActive := i == p.CursorIndex
+						radio := t.Radio(isActive)
+						label := t.Option(option.Label, isActive)
+						if isActive && option.Hint != "" {
+							radioOptions[i] = fmt.Sprintf("%s %s %s", radio, label, t.Hint(option.Hint))
 						} else {
-							radio := picocolors.Dim(symbols.RADIO_INACTIVE)
-							label := picocolors.Dim(option.Label)
 							radioOptions[i] = fmt.Sprintf("%s %s", radio, label)
 						}
 
@@ -108,9 +130,9 @@ func Select[TValue comparable](params SelectParams[TValue]) (TValue, error) {
 					}
 				}
 
-				if p.Filter {
+				if p.Filter && !p.LineMode {
 					if p.Search == "" {
-						message = fmt.Sprintf("%s\n> %s", message, picocolors.Inverse("T")+picocolors.Dim("ype to filter..."))
+						message = fmt.Sprintf("%s\n> %s", message, t.Placeholder("Type to filter...", true))
 					} else {
 						message = fmt.Sprintf("%s\n> %s", message, p.Search+"█")
 					}
@@ -124,9 +146,13 @@ func Select[TValue comparable](params SelectParams[TValue]) (TValue, error) {
 
 			return theme.ApplyTheme(theme.ThemeParams[TValue]{
 				Context:         p.Prompt,
+				Theme:           t,
 				Message:         message,
-				Value:           value,
+				Value:           formatValue(p.State, p.Value, params.FormatValue, value),
 				ValueWithCursor: value,
+				Options: lineOptions(p.LineMode, p.Options, func(option *core.SelectOption[TValue]) string {
+					return option.Label
+				}),
 			})
 		},
 	})
@@ -8,11 +8,13 @@ import (
 	"github.com/orochaa/go-clack/core"
 	"github.com/orochaa/go-clack/prompts"
 	"github.com/orochaa/go-clack/prompts/test"
+	"github.com/orochaa/go-clack/prompts/theme"
 	"github.com/stretchr/testify/assert"
 )
 
 func runSelect() {
 	prompts.Select(prompts.SelectParams[string]{
+		Input:   MockInput{},
 		Message: message,
 		Options: []*prompts.SelectOption[string]{
 			{Label: "foo"},
@@ -33,6 +35,7 @@ func TestSelectInitialState(t *testing.T) {
 
 func TestSelectWithHint(t *testing.T) {
 	go prompts.Select(prompts.SelectParams[string]{
+		Input:   MockInput{},
 		Message: message,
 		Options: []*prompts.SelectOption[string]{
 			{Label: "foo", Hint: "hint-foo"},
@@ -71,6 +74,7 @@ func TestSelectSubmitState(t *testing.T) {
 
 func TestSelectWithLongList(t *testing.T) {
 	go prompts.Select(prompts.SelectParams[string]{
+		Input:   MockInput{},
 		Message: message,
 		Options: []*prompts.SelectOption[string]{
 			{Label: "a"},
@@ -96,6 +100,7 @@ func TestSelectWithLongList(t *testing.T) {
 
 func TestSelectEmptyFilter(t *testing.T) {
 	go prompts.Select(prompts.SelectParams[string]{
+		Input:   MockInput{},
 		Message: message,
 		Filter:  true,
 		Options: []*prompts.SelectOption[string]{
@@ -114,6 +119,7 @@ func TestSelectEmptyFilter(t *testing.T) {
 
 func TestSelectFilledFilter(t *testing.T) {
 	go prompts.Select(prompts.SelectParams[string]{
+		Input:   MockInput{},
 		Message: message,
 		Filter:  true,
 		Options: []*prompts.SelectOption[string]{
@@ -130,3 +136,29 @@ func TestSelectFilledFilter(t *testing.T) {
 	assert.Equal(t, core.ActiveState, p.State)
 	cupaloy.SnapshotT(t, p.Frame)
 }
+
+func TestSelectWithTheme(t *testing.T) {
+	go prompts.Select(prompts.SelectParams[string]{
+		Input:   MockInput{},
+		Message: message,
+		Options: []*prompts.SelectOption[string]{
+			{Label: "foo", Hint: "hint-foo"},
+			{Label: "bar"},
+		},
+		Theme: theme.MinimalTheme{},
+	})
+	time.Sleep(time.Millisecond)
+	p := test.SelectTestingPrompt.(*core.SelectPrompt[string])
+
+	assert.Equal(t, core.InitialState, p.State)
+	cupaloy.SnapshotT(t, p.Frame)
+}
+
+func TestSelectLineMode(t *testing.T) {
+	go runSelect()
+	time.Sleep(time.Millisecond)
+	p := test.SelectTestingPrompt.(*core.SelectPrompt[string])
+	p.LineMode = true
+
+	assert.Equal(t, message+"\n  1. foo\n  2. bar\n  3. baz\n> ", p.Render(&p.Prompt))
+}
@@ -13,7 +13,7 @@ import (
 
 	"github.com/orochaa/go-clack/core"
 	"github.com/orochaa/go-clack/core/utils"
-	"github.com/orochaa/go-clack/prompts/symbols"
+	"github.com/orochaa/go-clack/prompts/theme"
 	isunicodesupported "github.com/orochaa/go-clack/third_party/is-unicode-supported"
 	"github.com/orochaa/go-clack/third_party/picocolors"
 	"github.com/orochaa/go-clack/third_party/sisteransi"
@@ -40,6 +40,7 @@ type SpinnerOptions struct {
 	OnCancel      func()
 	CancelMessage string
 	ErrorMessage  string
+	Theme         theme.Theme
 }
 
 type SpinnerController struct {
@@ -111,7 +112,9 @@ func (s *SpinnerController) clearMessage(msg string) {
 // Starts the spinner animation with the provided message
 func (s *SpinnerController) Start(msg string) {
 	s.write(sisteransi.HideCursor())
-	s.write(picocolors.Gray(symbols.BAR) + "\r\n")
+	if bar := grayBar(theme.Resolve(s.options.Theme).Bar()); bar != "" {
+		s.write(bar + "\r\n")
+	}
 
 	s.ticker.Reset(s.options.FrameInterval)
 
@@ -183,15 +186,15 @@ func (s *SpinnerController) Message(msg string) {
 func (s *SpinnerController) Stop(msg string, code int) {
 	s.stop()
 	s.clearMessage(s.message)
-	var step string
+	t := theme.Resolve(s.options.Theme)
+	state := core.ErrorState
 	switch code {
 	case 0:
-		step = picocolors.Green(symbols.STEP_SUBMIT)
+		state = core.SubmitState
 	case 1:
-		step = picocolors.Red(symbols.STEP_CANCEL)
-	default:
-		step = picocolors.Red(symbols.STEP_ERROR)
+		state = core.CancelState
 	}
+	step := t.SymbolColor(state)(t.Symbol(state))
 	if msg != "" {
 		dotsRegex := regexp.MustCompile(`\.{2,}$`)
 		s.message = dotsRegex.ReplaceAllString(msg, ".")
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/orochaa/go-clack/prompts"
+	"github.com/orochaa/go-clack/prompts/theme"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -118,3 +119,37 @@ func TestSpinnerStopMessage(t *testing.T) {
 
 	assert.Contains(t, w.Data, "◇ Loaded\n")
 }
+
+func TestSpinnerWithTheme(t *testing.T) {
+	w := &MockWriter{}
+	s := prompts.Spinner(prompts.SpinnerOptions{
+		Output:        w,
+		FrameInterval: time.Millisecond,
+		Theme:         theme.MinimalTheme{},
+	})
+
+	s.Start("Loading...")
+	time.Sleep(2 * time.Millisecond)
+	s.Stop("Failed", 1)
+	time.Sleep(1 * time.Millisecond)
+
+	assert.Equal(t, " \r\n", w.Data[1])
+	assert.Contains(t, w.Data, "✖ Failed\n")
+}
+
+func TestSpinnerWithCompactTheme(t *testing.T) {
+	w := &MockWriter{}
+	s := prompts.Spinner(prompts.SpinnerOptions{
+		Output:        w,
+		FrameInterval: time.Millisecond,
+		Theme:         theme.CompactTheme{},
+	})
+
+	s.Start("Loading...")
+	time.Sleep(2 * time.Millisecond)
+	s.Stop("Loaded", 0)
+	time.Sleep(1 * time.Millisecond)
+
+	assert.NotEqual(t, "\r\n", w.Data[1])
+	assert.Contains(t, w.Data, "✔ Loaded\n")
+}
@@ -32,8 +32,10 @@ var (
 	PASSWORD_MASK     Symbol = s("▪", "•")
 
 	BAR_H               Symbol = s("─", "-")
+	CORNER_TOP_LEFT     Symbol = s("╭", "+")
 	CORNER_TOP_RIGHT    Symbol = s("╮", "+")
 	CONNECT_LEFT        Symbol = s("├", "+")
+	CORNER_BOTTOM_LEFT  Symbol = s("╰", "+")
 	CORNER_BOTTOM_RIGHT Symbol = s("╯", "+")
 
 	INFO    Symbol = s("●", "•")
@@ -16,7 +16,7 @@ import (
 const message = "test message"
 
 func TestTextInitialState(t *testing.T) {
-	go prompts.Text(prompts.TextParams{Message: message})
+	go prompts.Text(prompts.TextParams{Input: MockInput{}, Message: message})
 	time.Sleep(time.Millisecond)
 
 	p := test.TextTestingPrompt
@@ -28,7 +28,7 @@ func TestTextInitialState(t *testing.T) {
 }
 
 func TestTextInitialStateWithPlaceholder(t *testing.T) {
-	go prompts.Text(prompts.TextParams{Message: message, Placeholder: "foo"})
+	go prompts.Text(prompts.TextParams{Input: MockInput{}, Message: message, Placeholder: "foo"})
 	time.Sleep(time.Millisecond)
 
 	p := test.TextTestingPrompt
@@ -40,7 +40,7 @@ func TestTextInitialStateWithPlaceholder(t *testing.T) {
 }
 
 func TestTextInitialStateWithInitialValue(t *testing.T) {
-	go prompts.Text(prompts.TextParams{Message: message, InitialValue: "foo"})
+	go prompts.Text(prompts.TextParams{Input: MockInput{}, Message: message, InitialValue: "foo"})
 	time.Sleep(time.Millisecond)
 
 	p := test.TextTestingPrompt
@@ -52,7 +52,7 @@ func TestTextInitialStateWithInitialValue(t *testing.T) {
 }
 
 func TestTextErrorState(t *testing.T) {
-	go prompts.Text(prompts.TextParams{Message: message, InitialValue: "foo", Validate: func(value string) error {
+	go prompts.Text(prompts.TextParams{Input: MockInput{}, Message: message, InitialValue: "foo", Validate: func(value string) error {
 		return fmt.Errorf("invalid value: %s", value)
 	}})
 	time.Sleep(time.Millisecond)
@@ -69,7 +69,7 @@ func TestTextErrorState(t *testing.T) {
 }
 
 func TestTextCancelState(t *testing.T) {
-	go prompts.Text(prompts.TextParams{Message: message})
+	go prompts.Text(prompts.TextParams{Input: MockInput{}, Message: message})
 	time.Sleep(time.Millisecond)
 
 	p := test.TextTestingPrompt
@@ -83,7 +83,7 @@ func TestTextCancelState(t *testing.T) {
 }
 
 func TestTextCancelStateWithValue(t *testing.T) {
-	go prompts.Text(prompts.TextParams{Message: message, InitialValue: "foo"})
+	go prompts.Text(prompts.TextParams{Input: MockInput{}, Message: message, InitialValue: "foo"})
 	time.Sleep(time.Millisecond)
 
 	p := test.TextTestingPrompt
@@ -97,7 +97,7 @@ func TestTextCancelStateWithValue(t *testing.T) {
 }
 
 func TestTextSubmitState(t *testing.T) {
-	go prompts.Text(prompts.TextParams{Message: message, InitialValue: "foo"})
+	go prompts.Text(prompts.TextParams{Input: MockInput{}, Message: message, InitialValue: "foo"})
 	time.Sleep(time.Millisecond)
 
 	p := test.TextTestingPrompt
@@ -109,3 +109,20 @@ func TestTextSubmitState(t *testing.T) {
 	assert.Equal(t, core.SubmitState, p.State)
 	assert.Equal(t, expected, p.Frame)
 }
+
+func TestTextHistorySearchState(t *testing.T) {
+	history := core.NewMemoryHistory(0)
+	history.Add("foo", "bar")
+	go prompts.Text(prompts.TextParams{Input: MockInput{}, Message: message, History: history, HistoryID: "foo"})
+	time.Sleep(time.Millisecond)
+
+	p := test.TextTestingPrompt
+	p.PressKey(&core.Key{Name: "r", Ctrl: true})
+	p.PressKey(&core.Key{Name: "a", Char: "a"})
+
+	title := symbols.State(core.ActiveState) + " " + message
+	valueWithCursor := symbols.BAR + " (reverse-i-search)`a': bar█"
+	expected := strings.Join([]string{symbols.BAR, title, valueWithCursor, symbols.BAR_END}, "\r\n")
+	assert.Equal(t, core.ActiveState, p.State)
+	assert.Equal(t, expected, p.Frame)
+}
@@ -0,0 +1,121 @@
+package theme
+
+import (
+	"strings"
+	"time"
+
+	"github.com/orochaa/go-clack/core"
+	"github.com/orochaa/go-clack/core/utils"
+	"github.com/orochaa/go-clack/prompts/symbols"
+	"github.com/orochaa/go-clack/third_party/picocolors"
+)
+
+// ClackTheme is the default theme, a vertical bar on the left of the prompts linking their steps.
+type ClackTheme struct{}
+
+func (ClackTheme) Symbol(state core.State) string {
+	return symbols.State(state)
+}
+
+func (ClackTheme) SymbolColor(state core.State) func(input string) string {
+	switch state {
+	case core.ErrorState, core.CancelState:
+		return picocolors.Red
+	case core.SubmitState:
+		return picocolors.Green
+	default:
+		return picocolors.Cyan
+	}
+}
+
+func (ClackTheme) BarColor(state core.State) func(input string) string {
+	switch state {
+	case core.ErrorState:
+		return picocolors.Yellow
+	case core.InitialState, core.ActiveState:
+		return picocolors.Cyan
+	default:
+		return picocolors.Gray
+	}
+}
+
+func (ClackTheme) ValueColor(state core.State) func(input string) string {
+	switch state {
+	case core.CancelState:
+		return func(input string) string {
+			return picocolors.Strikethrough(picocolors.Dim(input))
+		}
+	case core.SubmitState, core.ValidateState:
+		return picocolors.Dim
+	default:
+		return func(input string) string { return input }
+	}
+}
+
+func (ClackTheme) BarStart() string {
+	return symbols.BAR_START
+}
+
+func (ClackTheme) Bar() string {
+	return symbols.BAR
+}
+
+func (ClackTheme) BarEnd() string {
+	return symbols.BAR_END
+}
+
+func (ClackTheme) Radio(active bool) string {
+	if active {
+		return picocolors.Green(symbols.RADIO_ACTIVE)
+	}
+	return picocolors.Dim(symbols.RADIO_INACTIVE)
+}
+
+func (ClackTheme) Checkbox(active, selected bool) string {
+	switch {
+	case selected:
+		return picocolors.Green(symbols.CHECKBOX_SELECTED)
+	case active:
+		return picocolors.Green(symbols.CHECKBOX_ACTIVE)
+	default:
+		return picocolors.Dim(symbols.CHECKBOX_INACTIVE)
+	}
+}
+
+func (ClackTheme) Option(label string, active bool) string {
+	if active {
+		return label
+	}
+	return picocolors.Dim(label)
+}
+
+func (ClackTheme) Hint(hint string) string {
+	if hint == "" {
+		return ""
+	}
+	return picocolors.Dim("(" + hint + ")")
+}
+
+func (ClackTheme) Highlight(text string) string {
+	return picocolors.Cyan(text)
+}
+
+func (ClackTheme) Placeholder(placeholder string, cursor bool) string {
+	if placeholder == "" {
+		return ""
+	}
+	if cursor {
+		first := utils.Graphemes(placeholder)[0]
+		return picocolors.Inverse(first) + picocolors.Dim(placeholder[len(first):])
+	}
+	return picocolors.Dim(placeholder)
+}
+
+func (ClackTheme) Error(line string) string {
+	return picocolors.Yellow(line)
+}
+
+func (ClackTheme) Validating(duration time.Duration) string {
+	dots := strings.Repeat(".", int(duration.Seconds())%4)
+	return picocolors.Dim("validating" + dots)
+}
@@ -0,0 +1,26 @@
+package theme
+
+var compactSeparator = s("›", ">")
+
+// CompactTheme is an alternate theme rendering each prompt as a single `? message › value` line,
+// with its options indented beneath, to be interleaved with other output.
+// Intro, Outro and the logs are also rendered without bars.
+type CompactTheme struct {
+	MinimalTheme
+}
+
+func (CompactTheme) Separator() string {
+	return compactSeparator
+}
+
+func (CompactTheme) BarStart() string {
+	return ""
+}
+
+func (CompactTheme) Bar() string {
+	return ""
+}
+
+func (CompactTheme) BarEnd() string {
+	return ""
+}
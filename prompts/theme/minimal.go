@@ -0,0 +1,93 @@
+package theme
+
+import (
+	"github.com/orochaa/go-clack/core"
+	isunicodesupported "github.com/orochaa/go-clack/third_party/is-unicode-supported"
+	"github.com/orochaa/go-clack/third_party/picocolors"
+)
+
+func s(c, fallback string) string {
+	if isunicodesupported.IsUnicodeSupported() {
+		return c
+	}
+	return fallback
+}
+
+var (
+	minimalStepActive = s("?", "?")
+	minimalStepSubmit = s("✔", "√")
+	minimalStepCancel = s("✖", "×")
+	minimalStepError  = s("!", "!")
+
+	minimalRadioActive      = s("❯", ">")
+	minimalCheckboxSelected = s("◉", "(*)")
+	minimalCheckboxInactive = s("◯", "( )")
+)
+
+// MinimalTheme is an alternate theme without the vertical bar, whose options are pointed by an arrow.
+// Any other part of the prompts is rendered as in ClackTheme.
+type MinimalTheme struct {
+	ClackTheme
+}
+
+func (MinimalTheme) Symbol(state core.State) string {
+	switch state {
+	case core.ErrorState:
+		return minimalStepError
+	case core.CancelState:
+		return minimalStepCancel
+	case core.SubmitState:
+		return minimalStepSubmit
+	default:
+		return minimalStepActive
+	}
+}
+
+func (MinimalTheme) SymbolColor(state core.State) func(input string) string {
+	switch state {
+	case core.ErrorState:
+		return picocolors.Yellow
+	case core.CancelState:
+		return picocolors.Red
+	case core.SubmitState:
+		return picocolors.Green
+	default:
+		return picocolors.Cyan
+	}
+}
+
+func (MinimalTheme) BarStart() string {
+	return " "
+}
+
+func (MinimalTheme) Bar() string {
+	return " "
+}
+
+func (MinimalTheme) BarEnd() string {
+	return " "
+}
+
+func (MinimalTheme) Radio(active bool) string {
+	if active {
+		return picocolors.Cyan(minimalRadioActive)
+	}
+	return " "
+}
+
+func (MinimalTheme) Checkbox(active, selected bool) string {
+	if selected {
+		return picocolors.Green(minimalCheckboxSelected)
+	}
+	if active {
+		return picocolors.Cyan(minimalCheckboxInactive)
+	}
+	return picocolors.Dim(minimalCheckboxInactive)
+}
+
+func (MinimalTheme) Option(label string, active bool) string {
+	if active {
+		return picocolors.Cyan(label)
+	}
+	return label
+}
@@ -1,14 +1,86 @@
 package theme
 
 import (
+	"fmt"
+	"math"
 	"strings"
+	"time"
 
 	"github.com/orochaa/go-clack/core"
 	"github.com/orochaa/go-clack/core/utils"
-	"github.com/orochaa/go-clack/prompts/symbols"
 	"github.com/orochaa/go-clack/third_party/picocolors"
 )
 
+// Theme defines the look of the prompts: their symbols, bars, colors and options.
+// ClackTheme is the default implementation, and MinimalTheme an alternate one.
+type Theme interface {
+	// Symbol returns the symbol displayed before the message of a prompt in the given state.
+	Symbol(state core.State) string
+	// SymbolColor returns the style of the symbol of a prompt in the given state.
+	SymbolColor(state core.State) func(input string) string
+	// BarColor returns the style of the bars of a prompt in the given state.
+	BarColor(state core.State) func(input string) string
+	// ValueColor returns the style of the value of a prompt in the given state.
+	ValueColor(state core.State) func(input string) string
+
+	// BarStart returns the glyph opening a sequence of prompts, used by Intro.
+	BarStart() string
+	// Bar returns the glyph on the left of each line of a prompt.
+	Bar() string
+	// BarEnd returns the glyph closing a prompt.
+	BarEnd() string
+
+	// Radio renders the radio of a single choice option.
+	Radio(active bool) string
+	// Checkbox renders the checkbox of a multiple choice option.
+	Checkbox(active, selected bool) string
+	// Option renders the label of an option, whether it is under the cursor or not.
+	Option(label string, active bool) string
+	// Hint renders the hint of an option, or an empty string if there is none.
+	Hint(hint string) string
+	// Highlight renders a text standing out of the prompt, such as keys and suggestions.
+	Highlight(text string) string
+	// Placeholder renders the placeholder of an empty input, with the cursor over its first character or not.
+	Placeholder(placeholder string, cursor bool) string
+
+	// Error renders a line of the error message of a prompt.
+	Error(line string) string
+	// Validating renders the message displayed while the value of a prompt is being validated.
+	Validating(duration time.Duration) string
+}
+
+// InlineTheme is implemented by the themes rendering each prompt as a single `? message › value` line,
+// with its options indented beneath, instead of linking the prompts with bars.
+type InlineTheme interface {
+	Theme
+	// Separator returns the glyph between the message and the value of a prompt.
+	Separator() string
+}
+
+var current Theme = ClackTheme{}
+
+// SetTheme sets the theme used by every prompt without its own theme.
+// A nil theme restores the default ClackTheme.
+func SetTheme(theme Theme) {
+	if theme == nil {
+		theme = ClackTheme{}
+	}
+	current = theme
+}
+
+// Current returns the theme used by every prompt without its own theme.
+func Current() Theme {
+	return current
+}
+
+// Resolve returns the given theme, or the current theme if it is nil.
+func Resolve(theme Theme) Theme {
+	if theme == nil {
+		return current
+	}
+	return theme
+}
+
 type ThemeValue interface {
 	string | any | []any
 }
@@ -19,38 +91,51 @@ type ThemeParams[TValue ThemeValue] struct {
 	Value           string
 	ValueWithCursor string
 	Placeholder     string
+	Theme           Theme
+	// Options are the labels of the options of the prompt, listed with their number beneath the question in line mode.
+	Options []string
 }
 
 func ApplyTheme[TValue ThemeValue](params ThemeParams[TValue]) string {
 	ctx := params.Context
 
+	if ctx.LineMode {
+		return applyLineModeTheme(params)
+	}
+
+	t := Resolve(params.Theme)
+	if inline, ok := t.(InlineTheme); ok {
+		return applyInlineTheme(params, inline)
+	}
+
 	frame := make([]string, 0, 4)
-	frame = append(frame, picocolors.Gray(symbols.BAR))
+	frame = append(frame, picocolors.Gray(t.Bar()))
 
-	symbolColor := SymbolColor(ctx.State)
-	barColor := BarColor(ctx.State)
-	title := ctx.FormatLines(utils.SplitLines(params.Message), core.FormatLinesOptions{
+	symbolColor := t.SymbolColor(ctx.State)
+	barColor := t.BarColor(ctx.State)
+	valueColor := t.ValueColor(ctx.State)
+	message := params.Message
+	if ctx.TimeoutRemaining > 0 {
+		message += " " + Countdown(ctx.TimeoutRemaining)
+	}
+	title := ctx.FormatLines(utils.SplitLines(message), core.FormatLinesOptions{
 		FirstLine: core.FormatLineOptions{
-			Start: symbolColor(symbols.State(ctx.State)),
+			Start: symbolColor(t.Symbol(ctx.State)),
 		},
 		NewLine: core.FormatLineOptions{
-			Start: barColor(symbols.BAR),
+			Start: barColor(t.Bar()),
 		},
 	})
 	frame = append(frame, title)
 
-	var valueWithCursor string
-	if params.ValueWithCursor == "█" && params.Placeholder != "" {
-		valueWithCursor = picocolors.Inverse(string(params.Placeholder[0])) + picocolors.Dim(params.Placeholder[1:])
-	} else {
-		valueWithCursor = params.ValueWithCursor
-	}
+	valueWithCursor := applyPlaceholder(params, t)
 
 	switch ctx.State {
 	case core.ErrorState:
 		value := ctx.FormatLines(utils.SplitLines(valueWithCursor), core.FormatLinesOptions{
 			Default: core.FormatLineOptions{
-				Start: barColor(symbols.BAR),
+				Start: barColor(t.Bar()),
+				Style: valueColor,
 			},
 		})
 		frame = append(frame, value)
@@ -58,11 +143,11 @@ func ApplyTheme[TValue ThemeValue](params ThemeParams[TValue]) string {
 		if ctx.Error != "" {
 			err := ctx.FormatLines(utils.SplitLines(ctx.Error), core.FormatLinesOptions{
 				Default: core.FormatLineOptions{
-					Start: barColor(symbols.BAR),
-					Style: picocolors.Yellow,
+					Start: barColor(t.Bar()),
+					Style: t.Error,
 				},
 				LastLine: core.FormatLineOptions{
-					Start: barColor(symbols.BAR_END),
+					Start: barColor(t.BarEnd()),
 				},
 			})
 			frame = append(frame, err)
@@ -71,24 +156,22 @@ func ApplyTheme[TValue ThemeValue](params ThemeParams[TValue]) string {
 	case core.CancelState:
 		value := ctx.FormatLines(utils.SplitLines(params.Value), core.FormatLinesOptions{
 			Default: core.FormatLineOptions{
-				Start: barColor(symbols.BAR),
-				Style: func(line string) string {
-					return picocolors.Strikethrough(picocolors.Dim(line))
-				},
+				Start: barColor(t.Bar()),
+				Style: valueColor,
 			},
 		})
 		frame = append(frame, value)
 
 		if params.Value != "" {
-			end := barColor(symbols.BAR)
+			end := barColor(t.Bar())
 			frame = append(frame, end)
 		}
 
 	case core.SubmitState:
 		value := ctx.FormatLines(utils.SplitLines(params.Value), core.FormatLinesOptions{
 			Default: core.FormatLineOptions{
-				Start: barColor(symbols.BAR),
-				Style: picocolors.Dim,
+				Start: barColor(t.Bar()),
+				Style: valueColor,
 			},
 		})
 		frame = append(frame, value)
@@ -96,45 +179,126 @@ func ApplyTheme[TValue ThemeValue](params ThemeParams[TValue]) string {
 	case core.ValidateState:
 		value := ctx.FormatLines(utils.SplitLines(params.Value), core.FormatLinesOptions{
 			Default: core.FormatLineOptions{
-				Start: barColor(symbols.BAR),
-				Style: picocolors.Dim,
+				Start: barColor(t.Bar()),
+				Style: valueColor,
 			},
 		})
-		dots := strings.Repeat(".", int(ctx.ValidationDuration.Seconds())%4)
-		validatingMsg := barColor(symbols.BAR_END) + " " + picocolors.Dim("validating"+dots)
+		validatingMsg := barColor(t.BarEnd()) + " " + t.Validating(ctx.ValidationDuration)
 		frame = append(frame, value, validatingMsg)
 
 	default:
 		value := ctx.FormatLines(utils.SplitLines(valueWithCursor), core.FormatLinesOptions{
 			Default: core.FormatLineOptions{
-				Start: barColor(symbols.BAR),
+				Start: barColor(t.Bar()),
+				Style: valueColor,
 			},
 		})
-		end := barColor(symbols.BAR_END)
+		end := barColor(t.BarEnd())
 		frame = append(frame, value, end)
 	}
 
 	return strings.Join(frame, "\r\n")
 }
 
-func SymbolColor(state core.State) func(input string) string {
-	switch state {
-	case core.ErrorState, core.CancelState:
-		return picocolors.Red
-	case core.SubmitState:
-		return picocolors.Green
-	default:
-		return picocolors.Cyan
+// applyPlaceholder returns the value of the prompt with its cursor, or its placeholder if the value is empty.
+func applyPlaceholder[TValue ThemeValue](params ThemeParams[TValue], t Theme) string {
+	if params.ValueWithCursor == "" && params.Placeholder != "" {
+		return t.Placeholder(params.Placeholder, false)
+	} else if params.ValueWithCursor == "█" && params.Placeholder != "" {
+		return t.Placeholder(params.Placeholder, true)
 	}
+	return params.ValueWithCursor
 }
 
-func BarColor(state core.State) func(input string) string {
-	switch state {
-	case core.ErrorState:
-		return picocolors.Yellow
-	case core.InitialState, core.ActiveState:
-		return picocolors.Cyan
+// applyInlineTheme renders the message and a single line value of the prompt on the same line,
+// while the extra lines of the message, a multiline value, such as a list of options, and the error are indented beneath.
+func applyInlineTheme[TValue ThemeValue](params ThemeParams[TValue], t InlineTheme) string {
+	const indent = "  "
+	ctx := params.Context
+
+	message := params.Message
+	if ctx.TimeoutRemaining > 0 {
+		message += " " + Countdown(ctx.TimeoutRemaining)
+	}
+	messageLines := utils.SplitLines(message)
+
+	var value string
+	switch ctx.State {
+	case core.SubmitState, core.CancelState, core.ValidateState:
+		value = params.Value
 	default:
-		return picocolors.Gray
+		value = applyPlaceholder(params, t)
+	}
+	valueLines := utils.SplitLines(value)
+	valueColor := t.ValueColor(ctx.State)
+
+	symbol := t.SymbolColor(ctx.State)(t.Symbol(ctx.State))
+	title := symbol + " " + messageLines[0] + " " + picocolors.Dim(t.Separator())
+	if len(valueLines) == 1 && valueLines[0] != "" {
+		title += " " + valueColor(valueLines[0])
+	}
+
+	frame := []string{title}
+	for _, line := range messageLines[1:] {
+		frame = append(frame, indent+line)
+	}
+	if len(valueLines) > 1 {
+		for _, line := range valueLines {
+			frame = append(frame, indent+valueColor(line))
+		}
+	}
+
+	switch ctx.State {
+	case core.ErrorState:
+		if ctx.Error != "" {
+			for _, line := range utils.SplitLines(ctx.Error) {
+				frame = append(frame, indent+t.Error(line))
+			}
+		}
+	case core.ValidateState:
+		frame = append(frame, indent+t.Validating(ctx.ValidationDuration))
 	}
+
+	return strings.Join(frame, "\r\n")
+}
+
+// applyLineModeTheme renders a plain question, without bars, symbols or cursor, used when the prompt runs in line mode.
+// The options of the prompt are listed beneath the question, numbered from 1, so they can be answered by their number.
+func applyLineModeTheme[TValue ThemeValue](params ThemeParams[TValue]) string {
+	question := params.Message
+	if params.Placeholder != "" {
+		question += " " + picocolors.Dim("("+params.Placeholder+")")
+	}
+	for i, option := range params.Options {
+		question += fmt.Sprintf("\n  %s %s", picocolors.Dim(fmt.Sprintf("%d.", i+1)), option)
+	}
+	if len(params.Options) > 0 {
+		question += "\n>"
+	}
+	question += " "
+
+	if params.Context.State == core.ErrorState {
+		return picocolors.Yellow(params.Context.Error) + "\n" + question
+	}
+	return question
+}
+
+// Countdown renders the remaining time of a prompt's timeout, in seconds rounded up.
+func Countdown(remaining time.Duration) string {
+	seconds := int(math.Ceil(remaining.Seconds()))
+	return picocolors.Dim(fmt.Sprintf("(%ds)", seconds))
+}
+
+// SymbolColor returns the style of the symbol of a prompt in the given state, from the current theme.
+//
+// Deprecated: use Current().SymbolColor instead.
+func SymbolColor(state core.State) func(input string) string {
+	return current.SymbolColor(state)
+}
+
+// BarColor returns the style of the bars of a prompt in the given state, from the current theme.
+//
+// Deprecated: use Current().BarColor instead.
+func BarColor(state core.State) func(input string) string {
+	return current.BarColor(state)
 }
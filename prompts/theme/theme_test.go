@@ -8,6 +8,7 @@ import (
 	"github.com/orochaa/go-clack/core"
 	"github.com/orochaa/go-clack/prompts/symbols"
 	"github.com/orochaa/go-clack/prompts/theme"
+	"github.com/orochaa/go-clack/third_party/picocolors"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -489,3 +490,180 @@ func TestApplyThemeValidateState(t *testing.T) {
 		})
 	}
 }
+
+func TestApplyThemeLineMode(t *testing.T) {
+	frame := theme.ApplyTheme(theme.ThemeParams[string]{
+		Context: core.Prompt[string]{
+			State:    core.InitialState,
+			LineMode: true,
+		},
+		Message:     "Test message",
+		Placeholder: "Placeholder",
+	})
+	assert.Equal(t, "Test message (Placeholder) ", frame)
+
+	frame = theme.ApplyTheme(theme.ThemeParams[string]{
+		Context: core.Prompt[string]{
+			State:    core.ErrorState,
+			Error:    "Error message",
+			LineMode: true,
+		},
+		Message: "Test message",
+	})
+	assert.Equal(t, "Error message\nTest message ", frame)
+	frame = theme.ApplyTheme(theme.ThemeParams[string]{
+		Context: core.Prompt[string]{
+			State:    core.InitialState,
+			LineMode: true,
+		},
+		Message: "Test message",
+		Options: []string{"foo", "bar"},
+	})
+	assert.Equal(t, "Test message\n  1. foo\n  2. bar\n> ", frame)
+}
+
+func TestApplyThemeCountdown(t *testing.T) {
+	frame := theme.ApplyTheme(theme.ThemeParams[string]{
+		Context: core.Prompt[string]{
+			State:            core.ActiveState,
+			TimeoutRemaining: 9200 * time.Millisecond,
+		},
+		Message:         "Test message",
+		ValueWithCursor: "Value",
+	})
+	assert.Equal(t, strings.Join([]string{
+		symbols.BAR,
+		symbols.STEP_ACTIVE + " Test message (10s)",
+		symbols.BAR + " Value",
+		symbols.BAR_END,
+	}, "\r\n"), frame)
+}
+
+func TestApplyCustomTheme(t *testing.T) {
+	frame := theme.ApplyTheme(theme.ThemeParams[string]{
+		Context: core.Prompt[string]{
+			State: core.ActiveState,
+		},
+		Message:         "Test message",
+		ValueWithCursor: "Value",
+		Theme:           theme.MinimalTheme{},
+	})
+	assert.Equal(t, strings.Join([]string{
+		" ",
+		theme.MinimalTheme{}.Symbol(core.ActiveState) + " Test message",
+		"  Value",
+		" ",
+	}, "\r\n"), frame)
+}
+
+func TestSetTheme(t *testing.T) {
+	defer theme.SetTheme(nil)
+
+	theme.SetTheme(theme.MinimalTheme{})
+	assert.Equal(t, theme.MinimalTheme{}, theme.Current())
+	assert.Equal(t, theme.MinimalTheme{}, theme.Resolve(nil))
+	assert.Equal(t, theme.ClackTheme{}, theme.Resolve(theme.ClackTheme{}))
+
+	frame := theme.ApplyTheme(theme.ThemeParams[string]{
+		Context: core.Prompt[string]{
+			State: core.SubmitState,
+		},
+		Message: "Test message",
+		Value:   "Value",
+	})
+	assert.Equal(t, strings.Join([]string{
+		" ",
+		theme.MinimalTheme{}.Symbol(core.SubmitState) + " Test message",
+		"  Value",
+	}, "\r\n"), frame)
+
+	theme.SetTheme(nil)
+	assert.Equal(t, theme.ClackTheme{}, theme.Current())
+}
+
+func TestApplyCompactTheme(t *testing.T) {
+	compact := theme.CompactTheme{}
+	title := func(state core.State) string {
+		return compact.Symbol(state) + " Test message " + compact.Separator()
+	}
+
+	testCases := []struct {
+		Description     string
+		State           core.State
+		Error           string
+		Value           string
+		ValueWithCursor string
+		Placeholder     string
+		Expected        string
+	}{
+		{
+			Description:     "ActiveState",
+			State:           core.ActiveState,
+			ValueWithCursor: "Value█",
+			Expected:        title(core.ActiveState) + " Value█",
+		},
+		{
+			Description: "ActiveStateWithPlaceholder",
+			State:       core.ActiveState,
+			Placeholder: "Placeholder",
+			Expected:    title(core.ActiveState) + " Placeholder",
+		},
+		{
+			Description:     "ActiveStateWithOptions",
+			State:           core.ActiveState,
+			ValueWithCursor: "> foo\r\n  bar",
+			Expected: strings.Join([]string{
+				title(core.ActiveState),
+				"  > foo",
+				"    bar",
+			}, "\r\n"),
+		},
+		{
+			Description:     "ErrorState",
+			State:           core.ErrorState,
+			Error:           "Error message",
+			ValueWithCursor: "Value█",
+			Expected: strings.Join([]string{
+				title(core.ErrorState) + " Value█",
+				"  Error message",
+			}, "\r\n"),
+		},
+		{
+			Description: "SubmitState",
+			State:       core.SubmitState,
+			Value:       "Value",
+			Expected:    title(core.SubmitState) + " Value",
+		},
+		{
+			Description: "CancelState",
+			State:       core.CancelState,
+			Expected:    title(core.CancelState),
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.Description, func(t *testing.T) {
+			frame := theme.ApplyTheme(theme.ThemeParams[string]{
+				Context: core.Prompt[string]{
+					State: tC.State,
+					Error: tC.Error,
+				},
+				Message:         "Test message",
+				Value:           tC.Value,
+				ValueWithCursor: tC.ValueWithCursor,
+				Placeholder:     tC.Placeholder,
+				Theme:           compact,
+			})
+			assert.Equal(t, tC.Expected, frame)
+		})
+	}
+}
+
+func TestClackThemePlaceholder(t *testing.T) {
+	defer picocolors.SetProfile(picocolors.GetProfile())
+	picocolors.SetProfile(picocolors.ANSIProfile)
+
+	placeholder := theme.ClackTheme{}.Placeholder("日本語", true)
+	assert.Equal(t, picocolors.Inverse("日")+picocolors.Dim("本語"), placeholder)
+	placeholder = theme.ClackTheme{}.Placeholder("👍🏽!", true)
+	assert.Equal(t, picocolors.Inverse("👍🏽")+picocolors.Dim("!"), placeholder)
+}
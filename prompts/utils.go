@@ -31,3 +31,32 @@ func ExitOnError(err error) {
 	Error(err.Error())
 	os.Exit(1)
 }
+
+// formatValue returns the value displayed by a prompt, formatted by its FormatValue param once the prompt is submitted or cancelled.
+// Otherwise, or if the param is nil, the default value is returned.
+func formatValue[TValue any](state core.State, value TValue, format func(value TValue) string, defaultValue string) string {
+	if format != nil && (state == core.SubmitState || state == core.CancelState) {
+		return format(value)
+	}
+	return defaultValue
+}
+
+// lineOptions returns the labels of the options a prompt lists in line mode, or nil outside of line mode.
+func lineOptions[TOption any](lineMode bool, options []TOption, label func(option TOption) string) []string {
+	if !lineMode {
+		return nil
+	}
+	labels := make([]string, len(options))
+	for i, option := range options {
+		labels[i] = label(option)
+	}
+	return labels
+}
+
+// linePlaceholder returns the hint a prompt displays beside its question in line mode, or an empty string outside of line mode.
+func linePlaceholder(lineMode bool, hint string) string {
+	if !lineMode {
+		return ""
+	}
+	return hint
+}
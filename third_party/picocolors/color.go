@@ -0,0 +1,174 @@
+package picocolors
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Ansi256 returns a formatter coloring the foreground with a color of the 256 colors palette.
+// On terminals with the ANSI profile, the color is downsampled to the closest basic color.
+func Ansi256(code uint8) func(input string) string {
+	return ansi256Formatter(code, false)
+}
+
+// BgAnsi256 returns a formatter coloring the background with a color of the 256 colors palette.
+// On terminals with the ANSI profile, the color is downsampled to the closest basic color.
+func BgAnsi256(code uint8) func(input string) string {
+	return ansi256Formatter(code, true)
+}
+
+// RGB returns a formatter coloring the foreground with a 24-bit color.
+// The color is downsampled to the closest color supported by the profile of the terminal.
+func RGB(r, g, b uint8) func(input string) string {
+	return rgbFormatter(r, g, b, false)
+}
+
+// BgRGB returns a formatter coloring the background with a 24-bit color.
+// The color is downsampled to the closest color supported by the profile of the terminal.
+func BgRGB(r, g, b uint8) func(input string) string {
+	return rgbFormatter(r, g, b, true)
+}
+
+// Hex returns a formatter coloring the foreground with a hex color, such as "#ff8800" or "f80".
+// The input is returned unchanged if the hex color is invalid.
+func Hex(hex string) func(input string) string {
+	r, g, b, ok := parseHex(hex)
+	if !ok {
+		return func(input string) string { return input }
+	}
+	return RGB(r, g, b)
+}
+
+// BgHex returns a formatter coloring the background with a hex color, such as "#ff8800" or "f80".
+// The input is returned unchanged if the hex color is invalid.
+func BgHex(hex string) func(input string) string {
+	r, g, b, ok := parseHex(hex)
+	if !ok {
+		return func(input string) string { return input }
+	}
+	return BgRGB(r, g, b)
+}
+
+func rgbFormatter(r, g, b uint8, bg bool) func(input string) string {
+	return func(input string) string {
+		var open string
+		switch GetProfile() {
+		case NoColorProfile:
+			return input
+		case TrueColorProfile:
+			open = fmt.Sprintf("\x1b[%d;2;%d;%d;%dm", extendedCode(bg), r, g, b)
+		case ANSI256Profile:
+			open = fmt.Sprintf("\x1b[%d;5;%dm", extendedCode(bg), rgbToAnsi256(r, g, b))
+		default:
+			open = fmt.Sprintf("\x1b[%dm", ansi256ToAnsi(rgbToAnsi256(r, g, b), bg))
+		}
+		return colorFormatter(open, bg)(input)
+	}
+}
+
+func ansi256Formatter(code uint8, bg bool) func(input string) string {
+	return func(input string) string {
+		var open string
+		switch GetProfile() {
+		case NoColorProfile:
+			return input
+		case ANSIProfile:
+			open = fmt.Sprintf("\x1b[%dm", ansi256ToAnsi(code, bg))
+		default:
+			open = fmt.Sprintf("\x1b[%d;5;%dm", extendedCode(bg), code)
+		}
+		return colorFormatter(open, bg)(input)
+	}
+}
+
+// colorFormatter returns the formatter of a foreground or background color, given its opening sequence.
+func colorFormatter(open string, bg bool) func(input string) string {
+	if bg {
+		return formatter(open, "\x1b[49m", open)
+	}
+	return formatter(open, "\x1b[39m", open)
+}
+
+// extendedCode returns the SGR code introducing a 256 or 24-bit color.
+func extendedCode(bg bool) int {
+	if bg {
+		return 48
+	}
+	return 38
+}
+
+// rgbToAnsi256 returns the closest color of the 256 colors palette,
+// among the 6x6x6 color cube and the grayscale ramp for grays.
+func rgbToAnsi256(r, g, b uint8) uint8 {
+	if r == g && g == b {
+		if r < 8 {
+			return 16
+		}
+		if r > 248 {
+			return 231
+		}
+		return uint8(math.Round((float64(r)-8)/247*24)) + 232
+	}
+
+	cube := func(c uint8) uint8 {
+		return uint8(math.Round(float64(c) / 255 * 5))
+	}
+	return 16 + 36*cube(r) + 6*cube(g) + cube(b)
+}
+
+// ansi256ToAnsi returns the SGR code of the closest basic color to a color of the 256 colors palette.
+func ansi256ToAnsi(code uint8, bg bool) int {
+	offset := 0
+	if bg {
+		offset = 10
+	}
+
+	if code < 8 {
+		return 30 + int(code) + offset
+	}
+	if code < 16 {
+		return 90 + int(code) - 8 + offset
+	}
+
+	var r, g, b float64
+	if code >= 232 {
+		gray := (float64(code-232)*10 + 8) / 255
+		r, g, b = gray, gray, gray
+	} else {
+		code -= 16
+		remainder := code % 36
+		r = math.Floor(float64(code)/36) / 5
+		g = math.Floor(float64(remainder)/6) / 5
+		b = float64(remainder%6) / 5
+	}
+
+	value := max(r, g, b) * 2
+	if value == 0 {
+		return 30 + offset
+	}
+
+	result := 30 + (int(math.Round(b))<<2 | int(math.Round(g))<<1 | int(math.Round(r)))
+	if value == 2 {
+		result += 60
+	}
+	return result + offset
+}
+
+// parseHex parses a hex color of 3 or 6 digits, with or without a leading "#".
+func parseHex(hex string) (r, g, b uint8, ok bool) {
+	hex = strings.TrimPrefix(hex, "#")
+	if len(hex) == 3 {
+		hex = string([]byte{hex[0], hex[0], hex[1], hex[1], hex[2], hex[2]})
+	}
+	if len(hex) != 6 {
+		return 0, 0, 0, false
+	}
+
+	value, err := strconv.ParseUint(hex, 16, 32)
+	if err != nil {
+		return 0, 0, 0, false
+	}
+	return uint8(value >> 16), uint8(value >> 8), uint8(value), true
+}
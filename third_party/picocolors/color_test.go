@@ -0,0 +1,110 @@
+package picocolors
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRgbToAnsi256(t *testing.T) {
+	testCases := []struct {
+		r, g, b  uint8
+		expected uint8
+	}{
+		{r: 0, g: 0, b: 0, expected: 16},
+		{r: 255, g: 255, b: 255, expected: 231},
+		{r: 128, g: 128, b: 128, expected: 244},
+		{r: 255, g: 0, b: 0, expected: 196},
+		{r: 0, g: 255, b: 0, expected: 46},
+		{r: 0, g: 0, b: 255, expected: 21},
+		{r: 255, g: 128, b: 0, expected: 214},
+	}
+
+	for _, tC := range testCases {
+		t.Run(fmt.Sprintf("%d,%d,%d", tC.r, tC.g, tC.b), func(t *testing.T) {
+			assert.Equal(t, tC.expected, rgbToAnsi256(tC.r, tC.g, tC.b))
+		})
+	}
+}
+
+func TestAnsi256ToAnsi(t *testing.T) {
+	testCases := []struct {
+		code     uint8
+		bg       bool
+		expected int
+	}{
+		{code: 1, expected: 31},
+		{code: 1, bg: true, expected: 41},
+		{code: 9, expected: 91},
+		{code: 9, bg: true, expected: 101},
+		{code: 16, expected: 30},
+		{code: 21, expected: 94},
+		{code: 21, bg: true, expected: 104},
+		{code: 196, expected: 91},
+		{code: 231, expected: 97},
+		{code: 232, expected: 30},
+	}
+
+	for _, tC := range testCases {
+		t.Run(fmt.Sprintf("%d (bg: %t)", tC.code, tC.bg), func(t *testing.T) {
+			assert.Equal(t, tC.expected, ansi256ToAnsi(tC.code, tC.bg))
+		})
+	}
+}
+
+func TestParseHex(t *testing.T) {
+	testCases := []struct {
+		hex      string
+		r, g, b  uint8
+		expected bool
+	}{
+		{hex: "#ff8800", r: 255, g: 136, b: 0, expected: true},
+		{hex: "FF8800", r: 255, g: 136, b: 0, expected: true},
+		{hex: "#f80", r: 255, g: 136, b: 0, expected: true},
+		{hex: "abc", r: 170, g: 187, b: 204, expected: true},
+		{hex: "", expected: false},
+		{hex: "#", expected: false},
+		{hex: "#ff88", expected: false},
+		{hex: "#ff88001", expected: false},
+		{hex: "#ggg", expected: false},
+		{hex: "#12345z", expected: false},
+	}
+
+	for _, tC := range testCases {
+		t.Run(tC.hex, func(t *testing.T) {
+			r, g, b, ok := parseHex(tC.hex)
+			assert.Equal(t, tC.expected, ok)
+			assert.Equal(t, []uint8{tC.r, tC.g, tC.b}, []uint8{r, g, b})
+		})
+	}
+}
+
+func TestColorProfiles(t *testing.T) {
+	defer SetProfile(GetProfile())
+
+	testCases := []struct {
+		description string
+		profile     Profile
+		format      func(input string) string
+		expected    string
+	}{
+		{description: "RGB without colors", profile: NoColorProfile, format: RGB(255, 0, 0), expected: "foo"},
+		{description: "RGB in truecolor", profile: TrueColorProfile, format: RGB(255, 0, 0), expected: "\x1b[38;2;255;0;0mfoo\x1b[39m"},
+		{description: "RGB in 256 colors", profile: ANSI256Profile, format: RGB(255, 0, 0), expected: "\x1b[38;5;196mfoo\x1b[39m"},
+		{description: "RGB in ANSI", profile: ANSIProfile, format: RGB(255, 0, 0), expected: "\x1b[91mfoo\x1b[39m"},
+		{description: "BgRGB in truecolor", profile: TrueColorProfile, format: BgRGB(0, 0, 255), expected: "\x1b[48;2;0;0;255mfoo\x1b[49m"},
+		{description: "Ansi256 in truecolor", profile: TrueColorProfile, format: Ansi256(214), expected: "\x1b[38;5;214mfoo\x1b[39m"},
+		{description: "BgAnsi256 in ANSI", profile: ANSIProfile, format: BgAnsi256(21), expected: "\x1b[104mfoo\x1b[49m"},
+		{description: "Hex in 256 colors", profile: ANSI256Profile, format: Hex("#f80"), expected: "\x1b[38;5;214mfoo\x1b[39m"},
+		{description: "BgHex in ANSI", profile: ANSIProfile, format: BgHex("00f"), expected: "\x1b[104mfoo\x1b[49m"},
+		{description: "invalid Hex", profile: TrueColorProfile, format: Hex("nope"), expected: "foo"},
+	}
+
+	for _, tC := range testCases {
+		t.Run(tC.description, func(t *testing.T) {
+			SetProfile(tC.profile)
+			assert.Equal(t, tC.expected, tC.format("foo"))
+		})
+	}
+}
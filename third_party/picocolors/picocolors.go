@@ -1,63 +1,7 @@
 // Forked from https://github.com/alexeyraspopov/picocolors/blob/main/picocolors.js
 package picocolors
 
-import (
-	"os"
-	"strings"
-
-	"golang.org/x/term"
-)
-
-func isColorSupported() bool {
-	env := os.Environ()
-	argv := os.Args
-
-	noColor := false
-	for _, arg := range argv {
-		if arg == "--no-color" {
-			noColor = true
-			break
-		}
-	}
-
-	if noColor {
-		return false
-	}
-
-	forceColor := false
-	for _, arg := range argv {
-		if arg == "--color" {
-			forceColor = true
-			break
-		}
-	}
-
-	if forceColor {
-		return true
-	}
-
-	for _, v := range env {
-		if v == "FORCE_COLOR" {
-			return true
-		}
-	}
-
-	if os.Getenv("TERM") != "dumb" && term.IsTerminal(int(os.Stdout.Fd())) {
-		return true
-	}
-
-	for _, v := range env {
-		if v == "CI" {
-			return true
-		}
-	}
-
-	if os.Getenv("NO_COLOR") != "" {
-		return false
-	}
-
-	return false
-}
+import "strings"
 
 func formatter(open, close, replace string) func(string) string {
 	return func(input string) string {
@@ -87,10 +31,13 @@ func replaceClose(input, close, replace string, index int) string {
 
 func createColors() map[string]func(input string) string {
 	init := func(open, close, replace string) func(input string) string {
-		if isColorSupported() {
-			return formatter(open, close, replace)
+		format := formatter(open, close, replace)
+		return func(input string) string {
+			if GetProfile() == NoColorProfile {
+				return input
+			}
+			return format(input)
 		}
-		return func(input string) string { return input }
 	}
 
 	colors := map[string]func(string) string{
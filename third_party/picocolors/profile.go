@@ -0,0 +1,114 @@
+package picocolors
+
+import (
+	"os"
+	"strings"
+	"sync/atomic"
+
+	"golang.org/x/term"
+)
+
+// Profile is the color depth supported by the terminal.
+type Profile int32
+
+const (
+	// NoColorProfile disables colors and styles
+	NoColorProfile Profile = iota
+	// ANSIProfile supports the 16 basic ANSI colors
+	ANSIProfile
+	// ANSI256Profile supports the 256 colors palette
+	ANSI256Profile
+	// TrueColorProfile supports 24-bit RGB colors
+	TrueColorProfile
+)
+
+var profile atomic.Int32
+
+func init() {
+	profile.Store(int32(DetectProfile()))
+}
+
+// GetProfile returns the color profile used to render colors.
+func GetProfile() Profile {
+	return Profile(profile.Load())
+}
+
+// SetProfile overrides the detected color profile, e.g. to disable colors with NoColorProfile.
+func SetProfile(p Profile) {
+	profile.Store(int32(p))
+}
+
+// DetectProfile detects the color profile of the terminal from the arguments and environment of the program.
+//
+// Colors are disabled by the --no-color argument, a non-empty NO_COLOR or FORCE_COLOR set to "0" or "false".
+// They are forced by the --color argument or FORCE_COLOR, whose level "1", "2" or "3" sets the minimum profile.
+// Otherwise, colors are only enabled if stdout is a terminal that is not "dumb",
+// with the profile read from COLORTERM ("truecolor" or "24bit") and TERM (e.g. "xterm-256color").
+func DetectProfile() Profile {
+	for _, arg := range os.Args {
+		if arg == "--no-color" {
+			return NoColorProfile
+		}
+	}
+	if os.Getenv("NO_COLOR") != "" {
+		return NoColorProfile
+	}
+
+	minimum, forced := forcedProfile()
+	if forced && minimum == NoColorProfile {
+		return NoColorProfile
+	}
+
+	termName := os.Getenv("TERM")
+	if !forced && (termName == "dumb" || !term.IsTerminal(int(os.Stdout.Fd()))) {
+		return NoColorProfile
+	}
+	if termName == "dumb" {
+		return minimum
+	}
+
+	return max(minimum, envProfile(os.Getenv("COLORTERM"), termName))
+}
+
+// forcedProfile returns the minimum profile forced by the --color argument or the FORCE_COLOR environment variable.
+func forcedProfile() (minimum Profile, forced bool) {
+	for _, arg := range os.Args {
+		if arg == "--color" {
+			minimum, forced = ANSIProfile, true
+			break
+		}
+	}
+
+	value, ok := os.LookupEnv("FORCE_COLOR")
+	if !ok {
+		return minimum, forced
+	}
+	switch strings.ToLower(value) {
+	case "0", "false":
+		return NoColorProfile, true
+	case "2":
+		return ANSI256Profile, true
+	case "3":
+		return TrueColorProfile, true
+	default:
+		return max(minimum, ANSIProfile), true
+	}
+}
+
+// envProfile returns the profile advertised by the COLORTERM and TERM environment variables.
+func envProfile(colorTerm, termName string) Profile {
+	switch strings.ToLower(colorTerm) {
+	case "truecolor", "24bit":
+		return TrueColorProfile
+	}
+
+	termName = strings.ToLower(termName)
+	switch {
+	case strings.Contains(termName, "truecolor"), strings.Contains(termName, "24bit"), strings.HasSuffix(termName, "-direct"):
+		return TrueColorProfile
+	case strings.Contains(termName, "256"):
+		return ANSI256Profile
+	default:
+		return ANSIProfile
+	}
+}
@@ -0,0 +1,113 @@
+package picocolors
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// setEnv sets the environment variables for the duration of a test, unsetting the ones without value.
+func setEnv(t *testing.T, env map[string]string) {
+	for _, key := range []string{"NO_COLOR", "FORCE_COLOR", "COLORTERM", "TERM"} {
+		value, ok := env[key]
+		t.Setenv(key, value)
+		if !ok {
+			os.Unsetenv(key)
+		}
+	}
+}
+
+func TestDetectProfile(t *testing.T) {
+	testCases := []struct {
+		description string
+		env         map[string]string
+		expected    Profile
+	}{
+		{description: "NO_COLOR", env: map[string]string{"NO_COLOR": "1"}, expected: NoColorProfile},
+		{description: "NO_COLOR over FORCE_COLOR", env: map[string]string{"NO_COLOR": "1", "FORCE_COLOR": "3"}, expected: NoColorProfile},
+		{description: "FORCE_COLOR=0", env: map[string]string{"FORCE_COLOR": "0", "COLORTERM": "truecolor"}, expected: NoColorProfile},
+		{description: "FORCE_COLOR=false", env: map[string]string{"FORCE_COLOR": "false"}, expected: NoColorProfile},
+		{description: "FORCE_COLOR=1", env: map[string]string{"FORCE_COLOR": "1"}, expected: ANSIProfile},
+		{description: "FORCE_COLOR=3", env: map[string]string{"FORCE_COLOR": "3"}, expected: TrueColorProfile},
+		{description: "FORCE_COLOR below TERM", env: map[string]string{"FORCE_COLOR": "1", "TERM": "xterm-256color"}, expected: ANSI256Profile},
+		{description: "FORCE_COLOR below COLORTERM", env: map[string]string{"FORCE_COLOR": "2", "COLORTERM": "truecolor"}, expected: TrueColorProfile},
+		{description: "FORCE_COLOR with dumb TERM", env: map[string]string{"FORCE_COLOR": "2", "TERM": "dumb", "COLORTERM": "truecolor"}, expected: ANSI256Profile},
+	}
+
+	for _, tC := range testCases {
+		t.Run(tC.description, func(t *testing.T) {
+			setEnv(t, tC.env)
+			assert.Equal(t, tC.expected, DetectProfile())
+		})
+	}
+}
+
+func TestForcedProfile(t *testing.T) {
+	testCases := []struct {
+		description string
+		env         map[string]string
+		expected    Profile
+		forced      bool
+	}{
+		{description: "unset", env: map[string]string{}, expected: NoColorProfile, forced: false},
+		{description: "empty", env: map[string]string{"FORCE_COLOR": ""}, expected: ANSIProfile, forced: true},
+		{description: "0", env: map[string]string{"FORCE_COLOR": "0"}, expected: NoColorProfile, forced: true},
+		{description: "FALSE", env: map[string]string{"FORCE_COLOR": "FALSE"}, expected: NoColorProfile, forced: true},
+		{description: "1", env: map[string]string{"FORCE_COLOR": "1"}, expected: ANSIProfile, forced: true},
+		{description: "true", env: map[string]string{"FORCE_COLOR": "true"}, expected: ANSIProfile, forced: true},
+		{description: "2", env: map[string]string{"FORCE_COLOR": "2"}, expected: ANSI256Profile, forced: true},
+		{description: "3", env: map[string]string{"FORCE_COLOR": "3"}, expected: TrueColorProfile, forced: true},
+	}
+
+	for _, tC := range testCases {
+		t.Run(tC.description, func(t *testing.T) {
+			setEnv(t, tC.env)
+			minimum, forced := forcedProfile()
+			assert.Equal(t, tC.expected, minimum)
+			assert.Equal(t, tC.forced, forced)
+		})
+	}
+}
+
+func TestEnvProfile(t *testing.T) {
+	testCases := []struct {
+		colorTerm string
+		termName  string
+		expected  Profile
+	}{
+		{colorTerm: "truecolor", termName: "xterm", expected: TrueColorProfile},
+		{colorTerm: "24BIT", termName: "", expected: TrueColorProfile},
+		{colorTerm: "", termName: "xterm-truecolor", expected: TrueColorProfile},
+		{colorTerm: "", termName: "xterm-direct", expected: TrueColorProfile},
+		{colorTerm: "", termName: "xterm-256color", expected: ANSI256Profile},
+		{colorTerm: "", termName: "screen-256color", expected: ANSI256Profile},
+		{colorTerm: "", termName: "xterm", expected: ANSIProfile},
+		{colorTerm: "", termName: "", expected: ANSIProfile},
+	}
+
+	for _, tC := range testCases {
+		t.Run(tC.colorTerm+" "+tC.termName, func(t *testing.T) {
+			assert.Equal(t, tC.expected, envProfile(tC.colorTerm, tC.termName))
+		})
+	}
+}
+
+func TestSetProfile(t *testing.T) {
+	defer SetProfile(GetProfile())
+
+	testCases := []struct {
+		profile  Profile
+		expected string
+	}{
+		{profile: NoColorProfile, expected: "foo"},
+		{profile: ANSIProfile, expected: "\x1b[31mfoo\x1b[39m"},
+		{profile: TrueColorProfile, expected: "\x1b[31mfoo\x1b[39m"},
+	}
+
+	for _, tC := range testCases {
+		SetProfile(tC.profile)
+		assert.Equal(t, tC.profile, GetProfile())
+		assert.Equal(t, tC.expected, Red("foo"))
+	}
+}